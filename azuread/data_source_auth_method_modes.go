@@ -0,0 +1,214 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &authMethodModesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &authMethodModesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &authMethodModesDataSource{}
+)
+
+func NewAuthMethodModesDataSource() datasource.DataSource {
+	return &authMethodModesDataSource{}
+}
+
+type authMethodModesDataSource struct {
+	client *graph.GraphServiceClient
+}
+
+type authMethodModesDataSourceModel struct {
+	Strength types.String                `tfsdk:"strength"`
+	IDs      types.List                  `tfsdk:"ids"`
+	Modes    []authMethodModeDetailModel `tfsdk:"modes"`
+}
+
+type authMethodModeDetailModel struct {
+	ID                   types.String `tfsdk:"id"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	AuthenticationMethod types.String `tfsdk:"authentication_method"`
+	Strength             types.String `tfsdk:"strength"`
+}
+
+// authMethodModeStrength is the strength of an authentication method mode used
+// on its own.
+type authMethodModeStrength struct {
+	Mode     string
+	Strength string
+}
+
+// The known authentication method modes, in the order Microsoft Graph API lists
+// them in a combination. Microsoft Graph API does not return the strength of
+// the modes, so it is kept here as documented for the authentication
+// strengths.
+var authMethodModeStrengths = []authMethodModeStrength{
+	{"password", "singleFactor"},
+	{"voice", "singleFactor"},
+	{"hardwareOath", "singleFactor"},
+	{"softwareOath", "singleFactor"},
+	{"sms", "singleFactor"},
+	{"fido2", "multiFactor"},
+	{"windowsHelloForBusiness", "multiFactor"},
+	{"microsoftAuthenticatorPush", "singleFactor"},
+	{"deviceBasedPush", "multiFactor"},
+	{"temporaryAccessPassOneTime", "multiFactor"},
+	{"temporaryAccessPassMultiUse", "multiFactor"},
+	{"email", "singleFactor"},
+	{"x509CertificateSingleFactor", "singleFactor"},
+	{"x509CertificateMultiFactor", "multiFactor"},
+	{"federatedSingleFactor", "singleFactor"},
+	{"federatedMultiFactor", "multiFactor"},
+}
+
+var authMethodModeStrengthValues = []string{"singleFactor", "multiFactor"}
+
+func (d *authMethodModesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_method_modes"
+}
+
+func (d *authMethodModesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the authentication method modes that can be combined " +
+			"in the allowed combinations of the authentication strength policies, optionally filtered " +
+			"by strength. Will return all modes if input is empty.",
+		Attributes: map[string]schema.Attribute{
+			"strength": schema.StringAttribute{
+				Description: "Only return the modes of the strength. Possible values are `singleFactor` " +
+					"or `multiFactor`.",
+				Optional: true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "The IDs of the authentication method modes, as used in the allowed combinations.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"modes": schema.ListNestedAttribute{
+				Description: "The details of the authentication method modes.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the authentication method mode, e.g. `fido2`.",
+							Computed:    true,
+						},
+						"display_name": schema.StringAttribute{
+							Description: "The name of the authentication method mode.",
+							Computed:    true,
+						},
+						"authentication_method": schema.StringAttribute{
+							Description: "The authentication method of the mode, e.g. `microsoftAuthenticator`.",
+							Computed:    true,
+						},
+						"strength": schema.StringAttribute{
+							Description: "Whether the mode is `singleFactor` or `multiFactor` on its own. " +
+								"Null for the modes unknown to the provider.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *authMethodModesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(azureadClients).graphClient
+}
+
+func (d *authMethodModesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config authMethodModesDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Strength.IsNull() && !config.Strength.IsUnknown() &&
+		!slices.Contains(authMethodModeStrengthValues, config.Strength.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("strength"),
+			"[INPUT ERROR] Invalid Input",
+			fmt.Sprintf("'%v' is invalid, possible values are: %v", config.Strength.ValueString(),
+				strings.Join(authMethodModeStrengthValues, ", ")),
+		)
+	}
+}
+
+func (d *authMethodModesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state authMethodModesDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modeDetails, getModesDiags := getAuthMethodModeDetails(d.client)
+	resp.Diagnostics.Append(getModesDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modeIDs := []attr.Value{}
+	state.Strength = plan.Strength
+	state.Modes = []authMethodModeDetailModel{}
+	for _, mode := range modeDetails {
+		modeModel := authMethodModeDetailModel{
+			ID:                   types.StringValue(*mode.GetId()),
+			DisplayName:          types.StringPointerValue(mode.GetDisplayName()),
+			AuthenticationMethod: types.StringNull(),
+			Strength:             types.StringNull(),
+		}
+		if mode.GetAuthenticationMethod() != nil {
+			modeModel.AuthenticationMethod = types.StringValue(mode.GetAuthenticationMethod().String())
+		}
+		if strength := getAuthMethodModeStrength(*mode.GetId()); strength != "" {
+			modeModel.Strength = types.StringValue(strength)
+		}
+
+		if !plan.Strength.IsNull() && !plan.Strength.Equal(modeModel.Strength) {
+			continue
+		}
+
+		modeIDs = append(modeIDs, modeModel.ID)
+		state.Modes = append(state.Modes, modeModel)
+	}
+
+	state.IDs, diags = types.ListValue(types.StringType, modeIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// getAuthMethodModeStrength gets the strength of a known authentication method
+// mode, or an empty string for an unknown mode.
+func getAuthMethodModeStrength(mode string) string {
+	for _, modeStrength := range authMethodModeStrengths {
+		if modeStrength.Mode == mode {
+			return modeStrength.Strength
+		}
+	}
+	return ""
+}
@@ -0,0 +1,480 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &authMethodPoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &authMethodPoliciesDataSource{}
+)
+
+func NewAuthMethodPoliciesDataSource() datasource.DataSource {
+	return &authMethodPoliciesDataSource{}
+}
+
+type authMethodPoliciesDataSource struct {
+	client *graph.GraphServiceClient
+}
+
+type authMethodPoliciesDataSourceModel struct {
+	Types    types.List                  `tfsdk:"types"`
+	State    types.String                `tfsdk:"state"`
+	Policies []authMethodPolicyDataModel `tfsdk:"policies"`
+}
+
+type authMethodPolicyDataModel struct {
+	Type           types.String                   `tfsdk:"type"`
+	State          types.String                   `tfsdk:"state"`
+	ExcludeTargets []authMethodExcludeTargetModel `tfsdk:"exclude_targets"`
+	IncludeTargets []authMethodIncludeTargetModel `tfsdk:"include_targets"`
+
+	Fido2Settings                  *fido2SettingsModel                  `tfsdk:"fido2_settings"`
+	MicrosoftAuthenticatorSettings *microsoftAuthenticatorSettingsModel `tfsdk:"microsoft_authenticator_settings"`
+	TemporaryAccessPassSettings    *temporaryAccessPassSettingsModel    `tfsdk:"temporary_access_pass_settings"`
+	X509CertificateSettings        *x509CertificateSettingsModel        `tfsdk:"x509_certificate_settings"`
+	EmailSettings                  *emailSettingsModel                  `tfsdk:"email_settings"`
+	VoiceSettings                  *voiceSettingsModel                  `tfsdk:"voice_settings"`
+	QRCodePinSettings              *qrCodePinSettingsModel              `tfsdk:"qr_code_pin_settings"`
+}
+
+func (d *authMethodPoliciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_method_policies"
+}
+
+func (d *authMethodPoliciesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	authenticatorFeatureAttribute := func(description string) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			Description: description,
+			Computed:    true,
+			Attributes: map[string]schema.Attribute{
+				"state": schema.StringAttribute{
+					Description: "The state of the feature.",
+					Computed:    true,
+				},
+				"include_target_id": schema.StringAttribute{
+					Description: "The ID of the target the feature applies to.",
+					Computed:    true,
+				},
+				"include_target_type": schema.StringAttribute{
+					Description: "The type of the include target.",
+					Computed:    true,
+				},
+				"exclude_target_id": schema.StringAttribute{
+					Description: "The ID of the target excluded from the feature.",
+					Computed:    true,
+				},
+				"exclude_target_type": schema.StringAttribute{
+					Description: "The type of the exclude target.",
+					Computed:    true,
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the authentication method policies, optionally " +
+			"filtered by type or state. Will return all policies if input is empty.",
+		Attributes: map[string]schema.Attribute{
+			"types": schema.ListAttribute{
+				Description: "The types of the authentication method policies. Possible values are " +
+					"`Email`, `Fido2`, `MicrosoftAuthenticator`, `Voice`, `Sms`, `SoftwareOath`, " +
+					"`TemporaryAccessPass`, `X509Certificate`, `HardwareOath`, `QRCodePin`",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"state": schema.StringAttribute{
+				Description: "The state of the authentication method policies. Possible values are " +
+					"`enabled` or `disabled`.",
+				Optional: true,
+			},
+			"policies": schema.ListNestedAttribute{
+				Description: "The authentication method policies.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The type of the authentication method policy.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "Whether the authentication method policy is enabled in the tenant.",
+							Computed:    true,
+						},
+						"exclude_targets": schema.ListNestedAttribute{
+							Description: "The users or groups excluded from the authentication method policy.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Description: "The object ID of the excluded user or group.",
+										Computed:    true,
+									},
+									"target_type": schema.StringAttribute{
+										Description: "The type of the excluded target.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"include_targets": schema.ListNestedAttribute{
+							Description: "The users or groups the authentication method policy applies to.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Description: "The object ID of the included group, or `all_users`.",
+										Computed:    true,
+									},
+									"is_registration_required": schema.BoolAttribute{
+										Description: "Whether the targeted users are required to register the " +
+											"authentication method.",
+										Computed: true,
+									},
+									"authentication_mode": schema.StringAttribute{
+										Description: "The authentication mode allowed for the targeted users. Only " +
+											"set when `type` is `MicrosoftAuthenticator`.",
+										Computed: true,
+									},
+									"is_usable_for_sign_in": schema.BoolAttribute{
+										Description: "Whether the targeted users may use SMS to sign in. Only set " +
+											"when `type` is `Sms`.",
+										Computed: true,
+									},
+								},
+							},
+						},
+						"fido2_settings": schema.SingleNestedAttribute{
+							Description: "The settings of the passkey (FIDO2) authentication method.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"is_self_service_registration_allowed": schema.BoolAttribute{
+									Description: "Whether users are allowed to register a passkey (FIDO2) through self-service.",
+									Computed:    true,
+								},
+								"is_attestation_enforced": schema.BoolAttribute{
+									Description: "Whether the passkey (FIDO2) must provide an attestation during registration.",
+									Computed:    true,
+								},
+								"key_restrictions": schema.SingleNestedAttribute{
+									Description: "The restrictions of the passkeys (FIDO2) that may be registered.",
+									Computed:    true,
+									Attributes: map[string]schema.Attribute{
+										"is_enforced": schema.BoolAttribute{
+											Description: "Whether the key restrictions are enforced.",
+											Computed:    true,
+										},
+										"enforcement_type": schema.StringAttribute{
+											Description: "Whether the listed AAGUIDs are allowed or blocked.",
+											Computed:    true,
+										},
+										"aaguids": schema.SetAttribute{
+											Description: "The passkey (FIDO2) AAGUIDs allowed or blocked.",
+											Computed:    true,
+											ElementType: types.StringType,
+										},
+									},
+								},
+							},
+						},
+						"microsoft_authenticator_settings": schema.SingleNestedAttribute{
+							Description: "The settings of the Microsoft Authenticator authentication method.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"is_software_oath_enabled": schema.BoolAttribute{
+									Description: "Whether users may use the OTP code generated by the Microsoft Authenticator app.",
+									Computed:    true,
+								},
+								"display_app_information": authenticatorFeatureAttribute("Whether the name of the " +
+									"application requesting the authentication is shown in the notification."),
+								"display_location_information": authenticatorFeatureAttribute("Whether the geographic " +
+									"location of the sign in is shown in the notification."),
+								"number_matching": authenticatorFeatureAttribute("Whether number matching is " +
+									"required in the notification."),
+								"companion_app": authenticatorFeatureAttribute("Whether the Microsoft Authenticator " +
+									"companion applications may be used to approve notifications."),
+							},
+						},
+						"temporary_access_pass_settings": schema.SingleNestedAttribute{
+							Description: "The settings of the Temporary Access Pass authentication method.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"default_lifetime_in_minutes": schema.Int64Attribute{
+									Description: "The default lifetime of a Temporary Access Pass in minutes.",
+									Computed:    true,
+								},
+								"minimum_lifetime_in_minutes": schema.Int64Attribute{
+									Description: "The minimum lifetime of a Temporary Access Pass in minutes.",
+									Computed:    true,
+								},
+								"maximum_lifetime_in_minutes": schema.Int64Attribute{
+									Description: "The maximum lifetime of a Temporary Access Pass in minutes.",
+									Computed:    true,
+								},
+								"default_length": schema.Int64Attribute{
+									Description: "The default length of a Temporary Access Pass.",
+									Computed:    true,
+								},
+								"is_usable_once": schema.BoolAttribute{
+									Description: "Whether a Temporary Access Pass may only be used once.",
+									Computed:    true,
+								},
+							},
+						},
+						"x509_certificate_settings": schema.SingleNestedAttribute{
+							Description: "The settings of the certificate-based authentication method.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"default_authentication_mode": schema.StringAttribute{
+									Description: "The default authentication strength of the certificates.",
+									Computed:    true,
+								},
+								"default_required_affinity_level": schema.StringAttribute{
+									Description: "The default affinity level required for the certificates.",
+									Computed:    true,
+								},
+								"user_binding": schema.ListNestedAttribute{
+									Description: "The bindings of the certificate fields to the user properties.",
+									Computed:    true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"priority": schema.Int64Attribute{
+												Description: "The priority of the binding.",
+												Computed:    true,
+											},
+											"x509_certificate_field": schema.StringAttribute{
+												Description: "The field of the certificate.",
+												Computed:    true,
+											},
+											"user_property": schema.StringAttribute{
+												Description: "The property of the user.",
+												Computed:    true,
+											},
+											"trust_affinity_level": schema.StringAttribute{
+												Description: "The affinity level of the binding.",
+												Computed:    true,
+											},
+										},
+									},
+								},
+								"rule": schema.ListNestedAttribute{
+									Description: "The rules mapping certificates to an authentication strength.",
+									Computed:    true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"type": schema.StringAttribute{
+												Description: "The type of the rule.",
+												Computed:    true,
+											},
+											"identifier": schema.StringAttribute{
+												Description: "The issuer or policy OID matched by the rule.",
+												Computed:    true,
+											},
+											"issuer_subject_identifier": schema.StringAttribute{
+												Description: "The issuer subject matched by the rule.",
+												Computed:    true,
+											},
+											"policy_oid_identifier": schema.StringAttribute{
+												Description: "The policy OID matched by the rule.",
+												Computed:    true,
+											},
+											"authentication_mode": schema.StringAttribute{
+												Description: "The authentication strength of the matching certificates.",
+												Computed:    true,
+											},
+											"required_affinity_level": schema.StringAttribute{
+												Description: "The affinity level required for the matching certificates.",
+												Computed:    true,
+											},
+										},
+									},
+								},
+							},
+						},
+						"email_settings": schema.SingleNestedAttribute{
+							Description: "The settings of the Email OTP authentication method.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"allow_external_id_to_use_email_otp": schema.StringAttribute{
+									Description: "Whether external users may use Email OTP to sign in.",
+									Computed:    true,
+								},
+							},
+						},
+						"voice_settings": schema.SingleNestedAttribute{
+							Description: "The settings of the Voice call authentication method.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"is_office_phone_allowed": schema.BoolAttribute{
+									Description: "Whether users may register an office phone for voice calls.",
+									Computed:    true,
+								},
+							},
+						},
+						"qr_code_pin_settings": schema.SingleNestedAttribute{
+							Description: "The settings of the QR code authentication method.",
+							Computed:    true,
+							Attributes: map[string]schema.Attribute{
+								"pin_length": schema.Int64Attribute{
+									Description: "The length of the PIN used with the QR code.",
+									Computed:    true,
+								},
+								"standard_qr_code_lifetime_in_days": schema.Int64Attribute{
+									Description: "The lifetime of a standard QR code in days.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *authMethodPoliciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(azureadClients).graphClient
+}
+
+func (d *authMethodPoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state authMethodPoliciesDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	typeSlice, err := listOfStringsToSlice(plan.Types)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Input",
+			err.Error(),
+		)
+		return
+	}
+
+	for _, authMethodType := range typeSlice {
+		if !slices.Contains(authMethodTypes, authMethodType) {
+			resp.Diagnostics.AddError(
+				"[INPUT ERROR] Invalid Authentication Method Type",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are '%v'.",
+					authMethodType, strings.Join(authMethodTypes, "', '")),
+			)
+		}
+	}
+
+	switch plan.State.ValueString() {
+	case "", "enabled", "disabled":
+	default:
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Authentication Method State",
+			fmt.Sprintf("'%v' is invalid, only acceptable values are 'enabled' and 'disabled'.",
+				plan.State.ValueString()),
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the requested types are read, in the order they are requested.
+	requestedTypes := authMethodTypes
+	if len(typeSlice) > 0 {
+		requestedTypes = []string{}
+		for _, authMethodType := range typeSlice {
+			if !slices.Contains(requestedTypes, authMethodType) {
+				requestedTypes = append(requestedTypes, authMethodType)
+			}
+		}
+	}
+
+	authMethodConfigurations, getDiags := getAuthMethodConfigurations(d.client, requestedTypes)
+	resp.Diagnostics.Append(getDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Types = plan.Types
+	state.State = plan.State
+	state.Policies = []authMethodPolicyDataModel{}
+
+	for _, authMethodType := range requestedTypes {
+		config := authMethodConfigurations[authMethodType]
+		if config == nil || config.GetState() == nil {
+			continue
+		}
+		if !plan.State.IsNull() && config.GetState().String() != plan.State.ValueString() {
+			continue
+		}
+
+		// Every settings block of the type is read, the same way as a resource
+		// managing all of them.
+		policy := &authMethodPolicyResourceModel{
+			Type: types.StringValue(authMethodType),
+		}
+		switch authMethodType {
+		case "Fido2":
+			policy.Fido2Settings = &fido2SettingsModel{
+				KeyRestrictions: &fido2KeyRestrictionsModel{},
+			}
+		case "MicrosoftAuthenticator":
+			policy.MicrosoftAuthenticatorSettings = &microsoftAuthenticatorSettingsModel{
+				DisplayAppInformation:      &authenticatorFeatureModel{},
+				DisplayLocationInformation: &authenticatorFeatureModel{},
+				NumberMatching:             &authenticatorFeatureModel{},
+				CompanionApp:               &authenticatorFeatureModel{},
+			}
+		case "TemporaryAccessPass":
+			policy.TemporaryAccessPassSettings = &temporaryAccessPassSettingsModel{}
+		case "X509Certificate":
+			policy.X509CertificateSettings = &x509CertificateSettingsModel{}
+		case "Email":
+			policy.EmailSettings = &emailSettingsModel{}
+		case "Voice":
+			policy.VoiceSettings = &voiceSettingsModel{}
+		case "QRCodePin":
+			policy.QRCodePinSettings = &qrCodePinSettingsModel{}
+		}
+		refreshAuthMethodSettings(config, policy)
+
+		// Every user binding is read, as there is no prior state to follow.
+		if x509Config, ok := config.(graphModels.X509CertificateAuthenticationMethodConfigurationable); ok &&
+			policy.X509CertificateSettings != nil {
+			policy.X509CertificateSettings.UserBindings = getX509CertificateUserBindings(x509Config)
+		}
+
+		state.Policies = append(state.Policies, authMethodPolicyDataModel{
+			Type:                           policy.Type,
+			State:                          types.StringValue(config.GetState().String()),
+			ExcludeTargets:                 getExcludeTargets(config),
+			IncludeTargets:                 getIncludeTargets(config),
+			Fido2Settings:                  policy.Fido2Settings,
+			MicrosoftAuthenticatorSettings: policy.MicrosoftAuthenticatorSettings,
+			TemporaryAccessPassSettings:    policy.TemporaryAccessPassSettings,
+			X509CertificateSettings:        policy.X509CertificateSettings,
+			EmailSettings:                  policy.EmailSettings,
+			VoiceSettings:                  policy.VoiceSettings,
+			QRCodePinSettings:              policy.QRCodePinSettings,
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
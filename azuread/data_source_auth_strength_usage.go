@@ -0,0 +1,153 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                   = &authStrengthUsageDataSource{}
+	_ datasource.DataSourceWithConfigure      = &authStrengthUsageDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &authStrengthUsageDataSource{}
+)
+
+func NewAuthStrengthUsageDataSource() datasource.DataSource {
+	return &authStrengthUsageDataSource{}
+}
+
+type authStrengthUsageDataSource struct {
+	client *graph.GraphServiceClient
+}
+
+type authStrengthUsageDataSourceModel struct {
+	PolicyID types.String                       `tfsdk:"policy_id"`
+	Mfa      []conditionalAccessPolicyDataModel `tfsdk:"mfa"`
+	None     []conditionalAccessPolicyDataModel `tfsdk:"none"`
+}
+
+type conditionalAccessPolicyDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	DisplayName types.String `tfsdk:"display_name"`
+	State       types.String `tfsdk:"state"`
+}
+
+func (d *authStrengthUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_strength_usage"
+}
+
+func (d *authStrengthUsageDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	conditionalAccessPoliciesAttribute := func(description string) schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			Description: description,
+			Computed:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Description: "The ID of the conditional access policy.",
+						Computed:    true,
+					},
+					"display_name": schema.StringAttribute{
+						Description: "The name of the conditional access policy.",
+						Computed:    true,
+					},
+					"state": schema.StringAttribute{
+						Description: "The state of the conditional access policy, e.g. `enabled`, " +
+							"`disabled` or `enabledForReportingButNotEnforced`.",
+						Computed: true,
+					},
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the conditional access policies requiring an " +
+			"authentication strength policy.",
+		Attributes: map[string]schema.Attribute{
+			"policy_id": schema.StringAttribute{
+				Description: "The ID of the authentication strength policy, with or without the " +
+					"`/policies/authenticationStrengthPolicies/` prefix.",
+				Required: true,
+			},
+			"mfa": conditionalAccessPoliciesAttribute("The conditional access policies requiring the " +
+				"authentication strength policy that satisfy multifactor authentication."),
+			"none": conditionalAccessPoliciesAttribute("The conditional access policies requiring the " +
+				"authentication strength policy that do not satisfy multifactor authentication."),
+		},
+	}
+}
+
+func (d *authStrengthUsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(azureadClients).graphClient
+}
+
+func (d *authStrengthUsageDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config authStrengthUsageDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.PolicyID.IsNull() && !config.PolicyID.IsUnknown() &&
+		!guidRegex.MatchString(getAuthStrengthPolicyID(config.PolicyID.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policy_id"),
+			"[INPUT ERROR] Invalid Input",
+			fmt.Sprintf("'%v' is not a valid authentication strength policy ID.", config.PolicyID.ValueString()),
+		)
+	}
+}
+
+func (d *authStrengthUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state authStrengthUsageDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usage, getUsageDiags := getAuthStrengthUsage(d.client, getAuthStrengthPolicyID(plan.PolicyID.ValueString()))
+	resp.Diagnostics.Append(getUsageDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.PolicyID = plan.PolicyID
+	state.Mfa = getConditionalAccessPolicyDataModels(usage.GetMfa())
+	state.None = getConditionalAccessPolicyDataModels(usage.GetNone())
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func getConditionalAccessPolicyDataModels(policies []graphModels.ConditionalAccessPolicyable) []conditionalAccessPolicyDataModel {
+	policyModels := []conditionalAccessPolicyDataModel{}
+	for _, policy := range policies {
+		policyModel := conditionalAccessPolicyDataModel{
+			ID:          types.StringPointerValue(policy.GetId()),
+			DisplayName: types.StringPointerValue(policy.GetDisplayName()),
+			State:       types.StringNull(),
+		}
+		if policy.GetState() != nil {
+			policyModel.State = types.StringValue(policy.GetState().String())
+		}
+		policyModels = append(policyModels, policyModel)
+	}
+	return policyModels
+}
@@ -4,21 +4,24 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
-	"github.com/cenkalti/backoff"
 	graph "github.com/microsoftgraph/msgraph-sdk-go"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	graphPolicies "github.com/microsoftgraph/msgraph-sdk-go/policies"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ datasource.DataSource              = &authStrengthsDataSource{}
-	_ datasource.DataSourceWithConfigure = &authStrengthsDataSource{}
+	_ datasource.DataSource                   = &authStrengthsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &authStrengthsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &authStrengthsDataSource{}
 )
 
 func NewAuthStrengthsDataSource() datasource.DataSource {
@@ -32,6 +35,26 @@ type authStrengthsDataSource struct {
 type authStrengthsDataSourceModel struct {
 	AuthStrIDs   types.List `tfsdk:"ids"`
 	AuthStrNames types.List `tfsdk:"names"`
+	Strict       types.Bool `tfsdk:"strict"`
+
+	PolicyType            types.String `tfsdk:"policy_type"`
+	RequirementsSatisfied types.String `tfsdk:"requirements_satisfied"`
+	AllowsCombination     types.String `tfsdk:"allows_combination"`
+
+	Policies []authStrengthPolicyDataModel `tfsdk:"policies"`
+	ByName   types.Map                     `tfsdk:"by_name"`
+}
+
+type authStrengthPolicyDataModel struct {
+	ID                    types.String `tfsdk:"id"`
+	PathID                types.String `tfsdk:"path_id"`
+	DisplayName           types.String `tfsdk:"display_name"`
+	Description           types.String `tfsdk:"description"`
+	PolicyType            types.String `tfsdk:"policy_type"`
+	RequirementsSatisfied types.String `tfsdk:"requirements_satisfied"`
+	AllowedCombinations   types.List   `tfsdk:"allowed_combinations"`
+	CreatedDateTime       types.String `tfsdk:"created_date_time"`
+	ModifiedDateTime      types.String `tfsdk:"modified_date_time"`
 }
 
 func (d *authStrengthsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -41,10 +64,11 @@ func (d *authStrengthsDataSource) Metadata(_ context.Context, req datasource.Met
 func (d *authStrengthsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "This data source provides the authentication strength policies based on the list of ids" +
-			" or names of the policies. Will return all policies if input is empty.",
+			" or names of the policies, in the requested order. Will return all policies if input is empty.",
 		Attributes: map[string]schema.Attribute{
 			"ids": schema.ListAttribute{
-				Description: "The IDs of the authentication strength policy.",
+				Description: "The IDs of the authentication strength policy, with or without the " +
+					"`/policies/authenticationStrengthPolicies/` prefix.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
@@ -53,6 +77,80 @@ func (d *authStrengthsDataSource) Schema(_ context.Context, req datasource.Schem
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"strict": schema.BoolAttribute{
+				Description: "Whether to fail when any of the requested ids or names is not found. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"policy_type": schema.StringAttribute{
+				Description: "Only return the policies of the type. Possible values are `builtIn` or `custom`.",
+				Optional:    true,
+			},
+			"requirements_satisfied": schema.StringAttribute{
+				Description: "Only return the policies satisfying the requirements. Possible values are " +
+					"`mfa` or `none`.",
+				Optional: true,
+			},
+			"allows_combination": schema.StringAttribute{
+				Description: "Only return the policies allowing the authentication method combination, " +
+					"e.g. `fido2` or `password,microsoftAuthenticatorPush`.",
+				Optional: true,
+			},
+			"policies": schema.ListNestedAttribute{
+				Description: "The details of the authentication strength policies.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the authentication strength policy.",
+							Computed:    true,
+						},
+						"path_id": schema.StringAttribute{
+							Description: "The ID of the authentication strength policy prefixed with " +
+								"`/policies/authenticationStrengthPolicies/`, as returned in `ids`.",
+							Computed: true,
+						},
+						"display_name": schema.StringAttribute{
+							Description: "The name of the authentication strength policy.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the authentication strength policy.",
+							Computed:    true,
+						},
+						"policy_type": schema.StringAttribute{
+							Description: "Whether the authentication strength policy is `builtIn` or `custom`.",
+							Computed:    true,
+						},
+						"requirements_satisfied": schema.StringAttribute{
+							Description: "The requirements satisfied by the authentication strength policy, " +
+								"e.g. `mfa`.",
+							Computed: true,
+						},
+						"allowed_combinations": schema.ListAttribute{
+							Description: "The authentication method combinations allowed by the " +
+								"authentication strength policy.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"created_date_time": schema.StringAttribute{
+							Description: "The time the authentication strength policy was created, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"modified_date_time": schema.StringAttribute{
+							Description: "The time the authentication strength policy was last modified, " +
+								"in RFC 3339 format.",
+							Computed: true,
+						},
+					},
+				},
+			},
+			"by_name": schema.MapAttribute{
+				Description: "The IDs of the authentication strength policies keyed by name, in the " +
+					"same format as `ids`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -65,45 +163,66 @@ func (d *authStrengthsDataSource) Configure(_ context.Context, req datasource.Co
 	d.client = req.ProviderData.(azureadClients).graphClient
 }
 
-func (d *authStrengthsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var authStrengths models.AuthenticationStrengthPolicyCollectionResponseable
-	var err error
-	var plan, state authStrengthsDataSourceModel
-	var policyNames, policyIDs []attr.Value
-	diags := req.Config.Get(ctx, &plan)
+// The values accepted by the filters of the data source.
+var (
+	authStrengthPolicyTypes        = []string{"builtIn", "custom"}
+	authStrengthRequirementsValues = []string{"mfa", "none"}
+)
+
+func (d *authStrengthsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config authStrengthsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if len(plan.AuthStrIDs.Elements()) > 0 && len(plan.AuthStrNames.Elements()) > 0 {
+	if len(config.AuthStrIDs.Elements()) > 0 && len(config.AuthStrNames.Elements()) > 0 {
 		resp.Diagnostics.AddError(
 			"[INPUT ERROR] Invalid Input",
 			"Only one of 'ids' or 'names' may be specified, not both.",
 		)
-		return
 	}
 
-	getAuthStrengths := func() error {
-		if authStrengths, err = d.client.Policies().AuthenticationStrengthPolicies().Get(context.Background(), nil); err != nil {
-			return handleAPIError(err)
-		}
-		return nil
+	if !config.PolicyType.IsNull() && !config.PolicyType.IsUnknown() &&
+		!slices.Contains(authStrengthPolicyTypes, config.PolicyType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policy_type"),
+			"[INPUT ERROR] Invalid Input",
+			fmt.Sprintf("'%v' is invalid, possible values are: %v", config.PolicyType.ValueString(),
+				strings.Join(authStrengthPolicyTypes, ", ")),
+		)
 	}
 
-	reconnectBackoff := backoff.NewExponentialBackOff()
-	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	err = backoff.Retry(getAuthStrengths, reconnectBackoff)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"[API ERROR] Unable to Retrieve Authentication Strength Policy",
-			"An unexpected error occurred while retrieving the Authentication Strength Policy "+
-				"from Microsoft Entra ID via Microsoft Graph API. Please verify that the provided "+
-				"Authentication Strength Policy Name is correct and that the API permissions are "+
-				"correctly configured.\n\n"+
-				"Microsoft Graph API Error: "+err.Error(),
+	if !config.RequirementsSatisfied.IsNull() && !config.RequirementsSatisfied.IsUnknown() &&
+		!slices.Contains(authStrengthRequirementsValues, config.RequirementsSatisfied.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requirements_satisfied"),
+			"[INPUT ERROR] Invalid Input",
+			fmt.Sprintf("'%v' is invalid, possible values are: %v", config.RequirementsSatisfied.ValueString(),
+				strings.Join(authStrengthRequirementsValues, ", ")),
 		)
+	}
+
+	if !config.AllowsCombination.IsNull() && !config.AllowsCombination.IsUnknown() {
+		if _, err := getAuthMethodModes(config.AllowsCombination.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("allows_combination"),
+				"[INPUT ERROR] Invalid Input",
+				fmt.Sprintf("'%v' is an invalid combination, %v.", config.AllowsCombination.ValueString(), err),
+			)
+		}
+	}
+}
+
+func (d *authStrengthsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state authStrengthsDataSourceModel
+	var policyNames, policyIDs []attr.Value
+	policiesByName := map[string]attr.Value{}
+	state.Policies = []authStrengthPolicyDataModel{}
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -113,6 +232,7 @@ func (d *authStrengthsDataSource) Read(ctx context.Context, req datasource.ReadR
 			"[INPUT ERROR] Invalid Input",
 			err.Error(),
 		)
+		return
 	}
 	idSlice, err := listOfStringsToSlice(plan.AuthStrIDs)
 	if err != nil {
@@ -120,31 +240,89 @@ func (d *authStrengthsDataSource) Read(ctx context.Context, req datasource.ReadR
 			"[INPUT ERROR] Invalid Input",
 			err.Error(),
 		)
+		return
+	}
+
+	// The IDs may be given in the same format as the 'ids' output.
+	for i, id := range idSlice {
+		idSlice[i] = getAuthStrengthPolicyID(id)
 	}
 
-	// Filter policies based on matching name or ID
+	filter := getAuthStrengthsFilter(nameSlice, idSlice, plan.PolicyType.ValueString())
+	authStrengths, err := d.listAuthStrengths(filter)
+
+	// Fall back to filtering every policy on the client side when Microsoft
+	// Graph API does not support the filter.
+	if filter != "" && isBadRequestError(err) {
+		authStrengths, err = d.listAuthStrengths("")
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Retrieve Authentication Strength Policy",
+			"An unexpected error occurred while retrieving the Authentication Strength Policy "+
+				"from Microsoft Entra ID via Microsoft Graph API. Please verify that the provided "+
+				"Authentication Strength Policy Name is correct and that the API permissions are "+
+				"correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+
+	// Select policies based on matching name or ID, in the requested order,
+	// then apply the remaining filters to them.
+	selected, missing := authStrengths, []string(nil)
 	if len(nameSlice) != 0 {
-		for _, policy := range authStrengths.GetValue() {
-			displayName := *policy.GetDisplayName()
-			if slices.Contains(nameSlice, displayName) {
-				addPolicy(policy, &policyNames, &policyIDs)
-			}
-		}
+		selected, missing = selectAuthStrengths(authStrengths, nameSlice, func(policy models.AuthenticationStrengthPolicyable) string {
+			return *policy.GetDisplayName()
+		})
 	} else if len(idSlice) != 0 {
-		for _, policy := range authStrengths.GetValue() {
-			id := *policy.GetId()
-			if slices.Contains(idSlice, id) {
-				addPolicy(policy, &policyNames, &policyIDs)
+		selected, missing = selectAuthStrengths(authStrengths, idSlice, func(policy models.AuthenticationStrengthPolicyable) string {
+			return *policy.GetId()
+		})
+	}
 
-			}
+	var allowedCombination *models.AuthenticationMethodModes
+	if !plan.AllowsCombination.IsNull() {
+		combination, err := getAuthMethodModes(plan.AllowsCombination.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("allows_combination"),
+				"[INPUT ERROR] Invalid Input",
+				fmt.Sprintf("'%v' is an invalid combination, %v.", plan.AllowsCombination.ValueString(), err),
+			)
+			return
 		}
-	} else {
-		// If no input is provided, include all policy strengths
-		for _, policy := range authStrengths.GetValue() {
-			addPolicy(policy, &policyNames, &policyIDs)
+		allowedCombination = &combination
+	}
+
+	for _, policy := range selected {
+		if !plan.PolicyType.IsNull() &&
+			(policy.GetPolicyType() == nil || policy.GetPolicyType().String() != plan.PolicyType.ValueString()) {
+			continue
+		}
+		if !plan.RequirementsSatisfied.IsNull() && (policy.GetRequirementsSatisfied() == nil ||
+			policy.GetRequirementsSatisfied().String() != plan.RequirementsSatisfied.ValueString()) {
+			continue
+		}
+		if allowedCombination != nil && !slices.Contains(policy.GetAllowedCombinations(), *allowedCombination) {
+			continue
 		}
+		addPolicy(policy, &policyNames, &policyIDs, &state.Policies, policiesByName)
+	}
+
+	if plan.Strict.ValueBool() && len(missing) > 0 {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Authentication Strength Policy Not Found",
+			"The following authentication strength policies do not exist:\n\n"+strings.Join(missing, "\n"),
+		)
+		return
 	}
 
+	state.Strict = plan.Strict
+	state.PolicyType = plan.PolicyType
+	state.RequirementsSatisfied = plan.RequirementsSatisfied
+	state.AllowsCombination = plan.AllowsCombination
 	state.AuthStrIDs, diags = types.ListValue(types.StringType, policyIDs)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -157,6 +335,12 @@ func (d *authStrengthsDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
+	state.ByName, diags = types.MapValue(types.StringType, policiesByName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -164,9 +348,123 @@ func (d *authStrengthsDataSource) Read(ctx context.Context, req datasource.ReadR
 	}
 }
 
-func addPolicy(policy models.AuthenticationStrengthPolicyable, names, ids *[]attr.Value) {
+// listAuthStrengths lists every authentication strength policy matching the
+// OData filter, or every policy when the filter is empty.
+func (d *authStrengthsDataSource) listAuthStrengths(filter string) ([]models.AuthenticationStrengthPolicyable, error) {
+	var authStrengths []models.AuthenticationStrengthPolicyable
+
+	requestBuilder := d.client.Policies().AuthenticationStrengthPolicies()
+	var requestConfig *graphPolicies.AuthenticationStrengthPoliciesRequestBuilderGetRequestConfiguration
+	if filter != "" {
+		requestConfig = &graphPolicies.AuthenticationStrengthPoliciesRequestBuilderGetRequestConfiguration{
+			QueryParameters: &graphPolicies.AuthenticationStrengthPoliciesRequestBuilderGetQueryParameters{
+				Filter: &filter,
+			},
+		}
+	}
+
+	err := iteratePages(func(nextLink *string) (models.AuthenticationStrengthPolicyCollectionResponseable, error) {
+		if nextLink != nil {
+			return requestBuilder.WithUrl(*nextLink).Get(context.Background(), nil)
+		}
+		return requestBuilder.Get(context.Background(), requestConfig)
+	}, func(policy models.AuthenticationStrengthPolicyable) {
+		authStrengths = append(authStrengths, policy)
+	})
+
+	return authStrengths, err
+}
+
+// getAuthStrengthsFilter gets the OData filter matching the policies by name
+// or ID and by policy type. The results are still filtered on the client side,
+// so the filter only narrows down the policies returned by Microsoft Graph API.
+func getAuthStrengthsFilter(names, ids []string, policyType string) string {
+	var conditions []string
+	for _, name := range names {
+		conditions = append(conditions, fmt.Sprintf("displayName eq '%s'", strings.ReplaceAll(name, "'", "''")))
+	}
+	for _, id := range ids {
+		conditions = append(conditions, fmt.Sprintf("id eq '%s'", strings.ReplaceAll(id, "'", "''")))
+	}
+
+	filter := strings.Join(conditions, " or ")
+	if policyType != "" {
+		if filter != "" {
+			filter = fmt.Sprintf("(%s) and ", filter)
+		}
+		filter += fmt.Sprintf("policyType eq '%s'", policyType)
+	}
+
+	return filter
+}
+
+// selectAuthStrengths selects the policies whose key is requested, in the
+// requested order, and reports the requested keys matching no policy.
+func selectAuthStrengths(policies []models.AuthenticationStrengthPolicyable, requested []string,
+	getKey func(policy models.AuthenticationStrengthPolicyable) string) ([]models.AuthenticationStrengthPolicyable, []string) {
+	var selected []models.AuthenticationStrengthPolicyable
+	var missing []string
+
+	for i, value := range requested {
+		if slices.Contains(requested[:i], value) {
+			continue
+		}
+
+		found := false
+		for _, policy := range policies {
+			if getKey(policy) == value {
+				selected = append(selected, policy)
+				found = true
+			}
+		}
+		if !found {
+			missing = append(missing, value)
+		}
+	}
+
+	return selected, missing
+}
+
+func addPolicy(policy models.AuthenticationStrengthPolicyable, names, ids *[]attr.Value,
+	policies *[]authStrengthPolicyDataModel, byName map[string]attr.Value) {
+	pathID := types.StringValue(authStrengthPolicyPathPrefix + *policy.GetId())
 	*names = append(*names, types.StringValue(*policy.GetDisplayName()))
-	*ids = append(*ids, types.StringValue(fmt.Sprintf("/policies/authenticationStrengthPolicies/%s", *policy.GetId())))
+	*ids = append(*ids, pathID)
+	byName[*policy.GetDisplayName()] = pathID
+	*policies = append(*policies, getAuthStrengthPolicyDataModel(policy, pathID))
+}
+
+func getAuthStrengthPolicyDataModel(policy models.AuthenticationStrengthPolicyable, pathID types.String) authStrengthPolicyDataModel {
+	policyModel := authStrengthPolicyDataModel{
+		ID:                    types.StringPointerValue(policy.GetId()),
+		PathID:                pathID,
+		DisplayName:           types.StringPointerValue(policy.GetDisplayName()),
+		Description:           types.StringPointerValue(policy.GetDescription()),
+		PolicyType:            types.StringNull(),
+		RequirementsSatisfied: types.StringNull(),
+		CreatedDateTime:       types.StringNull(),
+		ModifiedDateTime:      types.StringNull(),
+	}
+	if policyType := policy.GetPolicyType(); policyType != nil {
+		policyModel.PolicyType = types.StringValue(policyType.String())
+	}
+	if requirements := policy.GetRequirementsSatisfied(); requirements != nil {
+		policyModel.RequirementsSatisfied = types.StringValue(requirements.String())
+	}
+	if created := policy.GetCreatedDateTime(); created != nil {
+		policyModel.CreatedDateTime = types.StringValue(created.Format(time.RFC3339))
+	}
+	if modified := policy.GetModifiedDateTime(); modified != nil {
+		policyModel.ModifiedDateTime = types.StringValue(modified.Format(time.RFC3339))
+	}
+
+	combinations := []attr.Value{}
+	for _, combination := range policy.GetAllowedCombinations() {
+		combinations = append(combinations, types.StringValue(combination.String()))
+	}
+	policyModel.AllowedCombinations = types.ListValueMust(types.StringType, combinations)
+
+	return policyModel
 }
 
 func listOfStringsToSlice(list types.List) ([]string, error) {
@@ -0,0 +1,366 @@
+package azuread
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = &temporaryAccessPassEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &temporaryAccessPassEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &temporaryAccessPassEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose          = &temporaryAccessPassEphemeralResource{}
+)
+
+func NewTemporaryAccessPassEphemeralResource() ephemeral.EphemeralResource {
+	return &temporaryAccessPassEphemeralResource{}
+}
+
+type temporaryAccessPassEphemeralResource struct {
+	client *graph.GraphServiceClient
+}
+
+type temporaryAccessPassEphemeralResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	UserID                types.String `tfsdk:"user_id"`
+	LifetimeInMinutes     types.Int64  `tfsdk:"lifetime_in_minutes"`
+	IsUsableOnce          types.Bool   `tfsdk:"is_usable_once"`
+	StartDateTime         types.String `tfsdk:"start_date_time"`
+	DeleteOnClose         types.Bool   `tfsdk:"delete_on_close"`
+	TemporaryAccessPass   types.String `tfsdk:"temporary_access_pass"`
+	IsUsable              types.Bool   `tfsdk:"is_usable"`
+	MethodUsabilityReason types.String `tfsdk:"method_usability_reason"`
+	CreatedDateTime       types.String `tfsdk:"created_date_time"`
+}
+
+// temporaryAccessPassPrivateData identifies the Temporary Access Pass to delete
+// when the ephemeral resource is closed.
+type temporaryAccessPassPrivateData struct {
+	UserID string `json:"user_id"`
+	ID     string `json:"id"`
+}
+
+const temporaryAccessPassPrivateKey = "temporary_access_pass"
+
+func (r *temporaryAccessPassEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_temporary_access_pass"
+}
+
+func (r *temporaryAccessPassEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Issues a Temporary Access Pass for a user. The pass is never stored in the state. " +
+			"The Temporary Access Pass authentication method policy must be enabled, and the inputs " +
+			"must be within its settings. Terraform opens ephemeral resources during `plan` as well as " +
+			"`apply`, so a Temporary Access Pass is issued on every run, and is deleted at the end of the " +
+			"run unless `delete_on_close` is `false`. As a user may only have one Temporary Access Pass, " +
+			"the pass issued during `plan` must be deleted for `apply` to issue another one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the Temporary Access Pass authentication method.",
+				Computed:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "The object ID or user principal name of the user.",
+				Required:    true,
+			},
+			"lifetime_in_minutes": schema.Int64Attribute{
+				Description: "The lifetime of the Temporary Access Pass in minutes. Defaults to the " +
+					"default lifetime of the Temporary Access Pass authentication method policy.",
+				Optional: true,
+				Computed: true,
+			},
+			"is_usable_once": schema.BoolAttribute{
+				Description: "Whether the Temporary Access Pass may only be used once. Defaults to the " +
+					"setting of the Temporary Access Pass authentication method policy.",
+				Optional: true,
+				Computed: true,
+			},
+			"start_date_time": schema.StringAttribute{
+				Description: "The time the Temporary Access Pass becomes usable, in RFC 3339 format. " +
+					"Defaults to the time it is issued.",
+				Optional: true,
+				Computed: true,
+			},
+			"delete_on_close": schema.BoolAttribute{
+				Description: "Whether to delete the Temporary Access Pass when the ephemeral resource is " +
+					"closed at the end of the Terraform run, including the pass issued during `plan`. " +
+					"Defaults to `true`. Only set it to `false` when the pass must outlive the run, e.g. " +
+					"with `terraform apply` only, as the next run fails while the pass exists.",
+				Optional: true,
+				Computed: true,
+			},
+			"temporary_access_pass": schema.StringAttribute{
+				Description: "The Temporary Access Pass.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"is_usable": schema.BoolAttribute{
+				Description: "Whether the Temporary Access Pass is usable.",
+				Computed:    true,
+			},
+			"method_usability_reason": schema.StringAttribute{
+				Description: "The reason the Temporary Access Pass is or is not usable, e.g. `EnabledByPolicy`.",
+				Computed:    true,
+			},
+			"created_date_time": schema.StringAttribute{
+				Description: "The time the Temporary Access Pass was issued, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *temporaryAccessPassEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(azureadClients).graphClient
+}
+
+func (r *temporaryAccessPassEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config temporaryAccessPassEphemeralResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.LifetimeInMinutes.IsNull() && !config.LifetimeInMinutes.IsUnknown() &&
+		(config.LifetimeInMinutes.ValueInt64() < tapMinLifetimeInMinutes ||
+			config.LifetimeInMinutes.ValueInt64() > tapMaxLifetimeInMinutes) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("lifetime_in_minutes"),
+			"[INPUT ERROR] Invalid Input",
+			fmt.Sprintf("The lifetime must be between %v and %v minutes.",
+				tapMinLifetimeInMinutes, tapMaxLifetimeInMinutes),
+		)
+	}
+
+	if !config.StartDateTime.IsNull() && !config.StartDateTime.IsUnknown() {
+		if _, err := time.Parse(time.RFC3339, config.StartDateTime.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("start_date_time"),
+				"[INPUT ERROR] Invalid Input",
+				fmt.Sprintf("'%v' is not a valid RFC 3339 time.", config.StartDateTime.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *temporaryAccessPassEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config temporaryAccessPassEphemeralResourceModel
+	var temporaryAccessPass graphModels.TemporaryAccessPassAuthenticationMethodable
+	var err error
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyDiags := r.checkTemporaryAccessPassPolicy(&config)
+	resp.Diagnostics.Append(policyDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DeleteOnClose.IsNull() {
+		config.DeleteOnClose = types.BoolValue(true)
+	}
+
+	requestBody := graphModels.NewTemporaryAccessPassAuthenticationMethod()
+	if !config.LifetimeInMinutes.IsNull() {
+		lifetimeInMinutes := int32(config.LifetimeInMinutes.ValueInt64())
+		requestBody.SetLifetimeInMinutes(&lifetimeInMinutes)
+	}
+	if !config.IsUsableOnce.IsNull() {
+		requestBody.SetIsUsableOnce(config.IsUsableOnce.ValueBoolPointer())
+	}
+	if !config.StartDateTime.IsNull() {
+		startDateTime, _ := time.Parse(time.RFC3339, config.StartDateTime.ValueString())
+		requestBody.SetStartDateTime(&startDateTime)
+	}
+
+	createTemporaryAccessPass := func() error {
+		temporaryAccessPass, err = r.client.Users().ByUserId(config.UserID.ValueString()).Authentication().
+			TemporaryAccessPassMethods().Post(context.Background(), requestBody, nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(createTemporaryAccessPass, reconnectBackoff)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Create Temporary Access Pass",
+			"An unexpected error occurred while creating the Temporary Access Pass "+
+				"on Microsoft Entra ID via Microsoft Graph API. Please verify that the provided "+
+				"user ID is correct and that the API permissions are "+
+				"correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+
+	result := config
+	result.ID = types.StringPointerValue(temporaryAccessPass.GetId())
+	result.TemporaryAccessPass = types.StringPointerValue(temporaryAccessPass.GetTemporaryAccessPass())
+	result.IsUsable = types.BoolPointerValue(temporaryAccessPass.GetIsUsable())
+	result.IsUsableOnce = types.BoolPointerValue(temporaryAccessPass.GetIsUsableOnce())
+	result.MethodUsabilityReason = types.StringPointerValue(temporaryAccessPass.GetMethodUsabilityReason())
+	result.LifetimeInMinutes = types.Int64Null()
+	if temporaryAccessPass.GetLifetimeInMinutes() != nil {
+		result.LifetimeInMinutes = types.Int64Value(int64(*temporaryAccessPass.GetLifetimeInMinutes()))
+	}
+	result.StartDateTime = types.StringNull()
+	if temporaryAccessPass.GetStartDateTime() != nil {
+		result.StartDateTime = types.StringValue(temporaryAccessPass.GetStartDateTime().Format(time.RFC3339))
+	}
+	result.CreatedDateTime = types.StringNull()
+	if temporaryAccessPass.GetCreatedDateTime() != nil {
+		result.CreatedDateTime = types.StringValue(temporaryAccessPass.GetCreatedDateTime().Format(time.RFC3339))
+	}
+
+	// Only the Temporary Access Passes to delete are remembered for Close.
+	if config.DeleteOnClose.ValueBool() && temporaryAccessPass.GetId() != nil {
+		privateData, err := json.Marshal(temporaryAccessPassPrivateData{
+			UserID: config.UserID.ValueString(),
+			ID:     *temporaryAccessPass.GetId(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[INPUT ERROR] Unable to Store Temporary Access Pass ID",
+				err.Error(),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, temporaryAccessPassPrivateKey, privateData)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+}
+
+func (r *temporaryAccessPassEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	rawPrivateData, getPrivateDiags := req.Private.GetKey(ctx, temporaryAccessPassPrivateKey)
+	resp.Diagnostics.Append(getPrivateDiags...)
+	if resp.Diagnostics.HasError() || rawPrivateData == nil {
+		return
+	}
+
+	var privateData temporaryAccessPassPrivateData
+	if err := json.Unmarshal(rawPrivateData, &privateData); err != nil {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Unable to Load Temporary Access Pass ID",
+			err.Error(),
+		)
+		return
+	}
+
+	deleteTemporaryAccessPass := func() error {
+		err := r.client.Users().ByUserId(privateData.UserID).Authentication().TemporaryAccessPassMethods().
+			ByTemporaryAccessPassAuthenticationMethodId(privateData.ID).Delete(context.Background(), nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(deleteTemporaryAccessPass, reconnectBackoff)
+
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Delete Temporary Access Pass",
+			"An unexpected error occurred while deleting the Temporary Access Pass "+
+				"from Microsoft Entra ID via Microsoft Graph API. Please verify that the API "+
+				"permissions are correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// checkTemporaryAccessPassPolicy checks the inputs against the Temporary Access
+// Pass authentication method policy, so that a pass the policy would reject is
+// reported with the setting it violates.
+func (r *temporaryAccessPassEphemeralResource) checkTemporaryAccessPassPolicy(config *temporaryAccessPassEphemeralResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	authMethodConfigurations, getConfigDiags := getAuthMethodConfigurations(r.client, []string{"TemporaryAccessPass"})
+	diags.Append(getConfigDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	authMethodConfiguration := authMethodConfigurations["TemporaryAccessPass"]
+	if authMethodConfiguration == nil || authMethodConfiguration.GetState() == nil ||
+		*authMethodConfiguration.GetState() != graphModels.ENABLED_AUTHENTICATIONMETHODSTATE {
+		diags.AddError(
+			"[INPUT ERROR] Temporary Access Pass Disabled",
+			"The Temporary Access Pass authentication method policy is disabled, so no Temporary "+
+				"Access Pass can be issued.",
+		)
+		return diags
+	}
+
+	tapConfiguration, ok := authMethodConfiguration.(graphModels.TemporaryAccessPassAuthenticationMethodConfigurationable)
+	if !ok {
+		return diags
+	}
+	settings := getTemporaryAccessPassSettings(tapConfiguration)
+
+	if !config.LifetimeInMinutes.IsNull() {
+		// A bound missing from the policy falls back to the range accepted by
+		// Graph API.
+		minimumLifetimeInMinutes := int64(tapMinLifetimeInMinutes)
+		if !settings.MinimumLifetimeInMinutes.IsNull() {
+			minimumLifetimeInMinutes = settings.MinimumLifetimeInMinutes.ValueInt64()
+		}
+		maximumLifetimeInMinutes := int64(tapMaxLifetimeInMinutes)
+		if !settings.MaximumLifetimeInMinutes.IsNull() {
+			maximumLifetimeInMinutes = settings.MaximumLifetimeInMinutes.ValueInt64()
+		}
+
+		lifetimeInMinutes := config.LifetimeInMinutes.ValueInt64()
+		if lifetimeInMinutes < minimumLifetimeInMinutes || lifetimeInMinutes > maximumLifetimeInMinutes {
+			diags.AddAttributeError(
+				path.Root("lifetime_in_minutes"),
+				"[INPUT ERROR] Invalid Input",
+				fmt.Sprintf("The lifetime of %v minutes is outside of the range of the Temporary Access Pass "+
+					"authentication method policy, which is between %v and %v minutes.", lifetimeInMinutes,
+					minimumLifetimeInMinutes, maximumLifetimeInMinutes),
+			)
+		}
+	}
+
+	if !config.IsUsableOnce.IsNull() && !config.IsUsableOnce.ValueBool() && settings.IsUsableOnce.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("is_usable_once"),
+			"[INPUT ERROR] Invalid Input",
+			"The Temporary Access Pass authentication method policy only allows Temporary Access Passes "+
+				"that may be used once.",
+		)
+	}
+
+	return diags
+}
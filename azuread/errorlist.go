@@ -1,6 +1,8 @@
 package azuread
 
 const (
+	ERR_BAD_REQUEST             = 400
+	ERR_NOT_FOUND               = 404
 	ERR_TOO_MANY_REQ            = 429
 	ERR_INTERNAL_ERROR          = 500
 	ERR_SERVICE_UNAVAILABLE     = 503
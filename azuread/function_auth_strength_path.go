@@ -0,0 +1,55 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = &authStrengthPathFunction{}
+)
+
+func NewAuthStrengthPathFunction() function.Function {
+	return &authStrengthPathFunction{}
+}
+
+type authStrengthPathFunction struct{}
+
+func (f *authStrengthPathFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "auth_strength_path"
+}
+
+func (f *authStrengthPathFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the path of an authentication strength policy.",
+		Description: "Returns the ID of an authentication strength policy prefixed with " +
+			"`/policies/authenticationStrengthPolicies/`, in the same format as the `ids` of the " +
+			"st-azuread_auth_strengths data source. A path is returned unchanged.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID of the authentication strength policy.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *authStrengthPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	policyID := getAuthStrengthPolicyID(id)
+	if !guidRegex.MatchString(policyID) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("'%v' is not a valid authentication "+
+			"strength policy ID.", id))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, authStrengthPolicyPathPrefix+policyID))
+}
@@ -0,0 +1,71 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = &builtinAuthStrengthIDFunction{}
+)
+
+func NewBuiltinAuthStrengthIDFunction() function.Function {
+	return &builtinAuthStrengthIDFunction{}
+}
+
+type builtinAuthStrengthIDFunction struct{}
+
+// The well-known IDs of the built-in authentication strength policies, which
+// are the same on every tenant.
+var builtinAuthStrengthIDs = map[string]string{
+	"mfa":                "00000000-0000-0000-0000-000000000002",
+	"passwordless_mfa":   "00000000-0000-0000-0000-000000000003",
+	"phishing_resistant": "00000000-0000-0000-0000-000000000004",
+}
+
+func (f *builtinAuthStrengthIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "builtin_auth_strength_id"
+}
+
+func (f *builtinAuthStrengthIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the ID of a built-in authentication strength policy.",
+		Description: "Returns the ID of a built-in authentication strength policy, without calling " +
+			"Microsoft Graph API. Possible names are `mfa`, `passwordless_mfa` or `phishing_resistant`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "name",
+				Description: "The name of the built-in authentication strength policy. Possible values " +
+					"are `mfa`, `passwordless_mfa` or `phishing_resistant`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *builtinAuthStrengthIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	id, ok := builtinAuthStrengthIDs[name]
+	if !ok {
+		names := []string{}
+		for builtinName := range builtinAuthStrengthIDs {
+			names = append(names, builtinName)
+		}
+		slices.Sort(names)
+
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("'%v' is not a built-in authentication "+
+			"strength policy, possible values are: %v", name, strings.Join(names, ", ")))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, id))
+}
@@ -0,0 +1,105 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &normalizeAuthCombinationsFunction{}
+)
+
+func NewNormalizeAuthCombinationsFunction() function.Function {
+	return &normalizeAuthCombinationsFunction{}
+}
+
+type normalizeAuthCombinationsFunction struct{}
+
+func (f *normalizeAuthCombinationsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_auth_combinations"
+}
+
+func (f *normalizeAuthCombinationsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validates and normalizes authentication method combinations.",
+		Description: "Validates each mode of the authentication method combinations against the modes " +
+			"known to the provider, and returns the combinations with the modes in the casing and order " +
+			"used by Microsoft Graph API, e.g. `microsoftAuthenticatorPush, Password` becomes " +
+			"`password,microsoftAuthenticatorPush`.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name: "combinations",
+				Description: "The authentication method combinations, each a comma separated list of " +
+					"authentication method modes.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *normalizeAuthCombinationsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var combinations []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &combinations))
+	if resp.Error != nil {
+		return
+	}
+
+	normalizedCombinations := []string{}
+	for i, combination := range combinations {
+		normalizedCombination, err := normalizeAuthCombination(combination)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Combination %d '%v' is invalid, %v.",
+				i, combination, err))
+			return
+		}
+
+		if index := slices.Index(normalizedCombinations, normalizedCombination); index >= 0 {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Combination %d '%v' is the same as "+
+				"combination %d '%v'.", i, combination, index, combinations[index]))
+			return
+		}
+		normalizedCombinations = append(normalizedCombinations, normalizedCombination)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalizedCombinations))
+}
+
+// normalizeAuthCombination normalizes the modes of a combination to the casing
+// and order of the known authentication method modes.
+func normalizeAuthCombination(combination string) (string, error) {
+	var modeIndexes []int
+
+	for _, mode := range splitAuthMethodModes(combination) {
+		if mode == "" {
+			return "", fmt.Errorf("the combination contains an empty mode")
+		}
+
+		index := slices.IndexFunc(authMethodModeStrengths, func(modeStrength authMethodModeStrength) bool {
+			return strings.EqualFold(modeStrength.Mode, mode)
+		})
+		if index < 0 {
+			return "", fmt.Errorf("'%v' is not a known authentication method mode", mode)
+		}
+		if slices.Contains(modeIndexes, index) {
+			return "", fmt.Errorf("'%v' is repeated", mode)
+		}
+		modeIndexes = append(modeIndexes, index)
+	}
+
+	slices.Sort(modeIndexes)
+
+	modes := []string{}
+	for _, index := range modeIndexes {
+		modes = append(modes, authMethodModeStrengths[index].Mode)
+	}
+
+	return strings.Join(modes, ","), nil
+}
@@ -1,12 +1,29 @@
 package azuread
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphGroups "github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
 )
 
+// The Microsoft Graph API beta endpoint, used for the features that are not
+// available in the v1.0 SDK yet.
+const graphBetaEndpoint = "https://graph.microsoft.com/beta"
+
 func handleAPIError(err error) error {
 	var graphErr *odataerrors.ODataError
 
@@ -20,3 +37,295 @@ func handleAPIError(err error) error {
 		return backoff.Permanent(err)
 	}
 }
+
+// isNotFoundError reports whether the error is a Microsoft Graph API error for
+// a missing object.
+func isNotFoundError(err error) bool {
+	var graphErr *odataerrors.ODataError
+	return errors.As(err, &graphErr) && graphErr.GetStatusCode() == ERR_NOT_FOUND
+}
+
+// isBadRequestError reports whether the error is a Microsoft Graph API error for
+// an invalid request, such as an unsupported query parameter.
+func isBadRequestError(err error) bool {
+	var graphErr *odataerrors.ODataError
+	return errors.As(err, &graphErr) && graphErr.GetStatusCode() == ERR_BAD_REQUEST
+}
+
+// newBetaRequestInfo creates a raw request to the Microsoft Graph API beta
+// endpoint, to be sent through the request adapter of the v1.0 Graph client.
+func newBetaRequestInfo(method abstractions.HttpMethod, resourcePath string) (*abstractions.RequestInformation, error) {
+	uri, err := url.Parse(graphBetaEndpoint + resourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	requestInfo := abstractions.NewRequestInformation()
+	requestInfo.Method = method
+	requestInfo.SetUri(*uri)
+	requestInfo.Headers.TryAdd("Accept", "application/json")
+
+	return requestInfo, nil
+}
+
+// sendBetaRequest sends a raw request to the Microsoft Graph API beta endpoint
+// and deserializes the response with the given factory. Errors are returned as
+// *odataerrors.ODataError, so that they are handled by handleAPIError the same
+// way as the errors of the v1.0 SDK.
+func sendBetaRequest(client *graph.GraphServiceClient, method abstractions.HttpMethod, resourcePath string,
+	body serialization.Parsable, factory serialization.ParsableFactory) (serialization.Parsable, error) {
+	requestInfo, err := newBetaRequestInfo(method, resourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter := client.GetAdapter()
+	if body != nil {
+		if err := requestInfo.SetContentFromParsable(context.Background(), adapter, "application/json", body); err != nil {
+			return nil, err
+		}
+	}
+
+	errorMapping := abstractions.ErrorMappings{
+		"XXX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+	}
+
+	if factory == nil {
+		return nil, adapter.SendNoContent(context.Background(), requestInfo, errorMapping)
+	}
+
+	return adapter.Send(context.Background(), requestInfo, factory, errorMapping)
+}
+
+// getRawString converts a raw JSON value deserialized as additional data into
+// a string.
+func getRawString(value any) (string, bool) {
+	switch v := value.(type) {
+	case *string:
+		if v == nil {
+			return "", false
+		}
+		return *v, true
+	case string:
+		return v, true
+	default:
+		return "", false
+	}
+}
+
+// getRawBool converts a raw JSON value deserialized as additional data into a
+// bool.
+func getRawBool(value any) (bool, bool) {
+	switch v := value.(type) {
+	case *bool:
+		if v == nil {
+			return false, false
+		}
+		return *v, true
+	case bool:
+		return v, true
+	default:
+		return false, false
+	}
+}
+
+// getRawInt64 converts a raw JSON number deserialized as additional data into
+// an int64.
+func getRawInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case *float64:
+		if v == nil {
+			return 0, false
+		}
+		return int64(*v), true
+	case float64:
+		return int64(v), true
+	case *int32:
+		if v == nil {
+			return 0, false
+		}
+		return int64(*v), true
+	case *int64:
+		if v == nil {
+			return 0, false
+		}
+		return *v, true
+	default:
+		return 0, false
+	}
+}
+
+// resolveGroupNames resolves each display name or mail nickname to the ID of
+// the only group matching it. Names matching no group or more than one group
+// are reported as errors.
+func resolveGroupNames(client *graph.GraphServiceClient, names []string) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	groupIDs := map[string]string{}
+
+	for _, name := range names {
+		var groups models.GroupCollectionResponseable
+		var err error
+
+		escapedName := strings.ReplaceAll(name, "'", "''")
+		filter := fmt.Sprintf("displayName eq '%s' or mailNickname eq '%s'", escapedName, escapedName)
+		top := int32(2)
+
+		getGroups := func() error {
+			groups, err = client.Groups().Get(context.Background(), &graphGroups.GroupsRequestBuilderGetRequestConfiguration{
+				QueryParameters: &graphGroups.GroupsRequestBuilderGetQueryParameters{
+					Filter: &filter,
+					Select: []string{"id", "displayName", "mailNickname"},
+					Top:    &top,
+				},
+			})
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err = backoff.Retry(getGroups, reconnectBackoff)
+
+		if err != nil {
+			diags.AddError(
+				"[API ERROR] Unable to Retrieve Group",
+				fmt.Sprintf("An unexpected error occurred while retrieving the group '%s' "+
+					"from Microsoft Entra ID via Microsoft Graph API. Please verify that the API "+
+					"permissions are correctly configured.\n\n"+
+					"Microsoft Graph API Error: %s", name, err.Error()),
+			)
+			continue
+		}
+
+		switch len(groups.GetValue()) {
+		case 0:
+			diags.AddError(
+				"[INPUT ERROR] Group Not Found",
+				fmt.Sprintf("No group has the display name or mail nickname '%s'.", name),
+			)
+		case 1:
+			groupIDs[name] = *groups.GetValue()[0].GetId()
+		default:
+			diags.AddError(
+				"[INPUT ERROR] Ambiguous Group Name",
+				fmt.Sprintf("More than one group has the display name or mail nickname '%s', "+
+					"use the group ID instead.", name),
+			)
+		}
+	}
+
+	return groupIDs, diags
+}
+
+// appendExcludedGroups appends the groups to the exclude targets of a policy,
+// skipping the groups that are already excluded.
+func appendExcludedGroups(targets []models.ExcludeTargetable, groupIDs []string) []models.ExcludeTargetable {
+	targetType := models.GROUP_AUTHENTICATIONMETHODTARGETTYPE
+	for _, groupID := range groupIDs {
+		if slices.ContainsFunc(targets, func(target models.ExcludeTargetable) bool {
+			return target.GetId() != nil && *target.GetId() == groupID
+		}) {
+			continue
+		}
+
+		excludedTarget := models.NewExcludeTarget()
+		excludedTarget.SetId(&groupID)
+		excludedTarget.SetTargetType(&targetType)
+		targets = append(targets, excludedTarget)
+	}
+
+	return targets
+}
+
+// collectionPage is a page of a collection returned by Microsoft Graph API.
+type collectionPage[T any] interface {
+	GetValue() []T
+	GetOdataNextLink() *string
+}
+
+// iteratePages gets every page of a collection by following the next links
+// returned by Microsoft Graph API, and calls handleItem with each item. getPage
+// gets the first page when nextLink is nil, and the page at nextLink otherwise.
+// The next link already carries the query parameters of the first request.
+func iteratePages[T any, P collectionPage[T]](getPage func(nextLink *string) (P, error), handleItem func(T)) error {
+	var nextLink *string
+	for {
+		var page P
+		getNextPage := func() error {
+			var err error
+			page, err = getPage(nextLink)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err := backoff.Retry(getNextPage, reconnectBackoff)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range page.GetValue() {
+			handleItem(item)
+		}
+
+		nextLink = page.GetOdataNextLink()
+		if nextLink == nil || *nextLink == "" {
+			return nil
+		}
+	}
+}
+
+// getGroupMemberIDs lists the IDs of the direct and nested members of a group.
+func getGroupMemberIDs(ctx context.Context, client *graph.GraphServiceClient, groupID string) (map[string]bool, error) {
+	memberIDs := map[string]bool{}
+	requestBuilder := client.Groups().ByGroupId(groupID).TransitiveMembers()
+	requestConfig := &graphGroups.ItemTransitiveMembersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &graphGroups.ItemTransitiveMembersRequestBuilderGetQueryParameters{
+			Select: []string{"id"},
+		},
+	}
+
+	err := iteratePages(func(nextLink *string) (models.DirectoryObjectCollectionResponseable, error) {
+		if nextLink != nil {
+			return requestBuilder.WithUrl(*nextLink).Get(ctx, nil)
+		}
+		return requestBuilder.Get(ctx, requestConfig)
+	}, func(member models.DirectoryObjectable) {
+		if member.GetId() != nil {
+			memberIDs[*member.GetId()] = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return memberIDs, nil
+}
+
+func setOfStringsToSlice(set types.Set) ([]string, error) {
+	if set.IsNull() || set.IsUnknown() {
+		return []string{}, nil
+	}
+	var result []string
+	for _, v := range set.Elements() {
+		strVal, ok := v.(types.String)
+		if !ok {
+			return nil, fmt.Errorf("expected types.String inside set, got %T", v)
+		}
+		result = append(result, strVal.ValueString())
+	}
+
+	return result, nil
+}
+
+func getStringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
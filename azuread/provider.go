@@ -2,12 +2,15 @@ package azuread
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	azureClient "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	graph "github.com/microsoftgraph/msgraph-sdk-go"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -18,11 +21,16 @@ import (
 // Wrapper of Azuread client
 type azureadClients struct {
 	graphClient *graph.GraphServiceClient
+
+	// The group IDs excluded from every policy managed by the provider.
+	alwaysExcludedGroupIDs []string
 }
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &azureadProvider{}
+	_ provider.Provider                       = &azureadProvider{}
+	_ provider.ProviderWithFunctions          = &azureadProvider{}
+	_ provider.ProviderWithEphemeralResources = &azureadProvider{}
 )
 
 // New is a helper function to simplify provider server
@@ -38,6 +46,8 @@ type azureadProviderModel struct {
 	TenantID     types.String `tfsdk:"tenant_id"`
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
+
+	AlwaysExcludedGroupIDs types.Set `tfsdk:"always_excluded_group_ids"`
 }
 
 // Metadata returns the provider type name.
@@ -63,6 +73,13 @@ func (p *azureadProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Description: "Client Secret for MS Graph API. May also be provided via AZURE_CLIENT_SECRET environment variable.",
 				Optional:    true,
 			},
+			"always_excluded_group_ids": schema.SetAttribute{
+				Description: "A set of group IDs, such as the emergency access accounts group, that are always " +
+					"excluded from the policies managed by the provider. They are merged into the exclusions " +
+					"of every policy and hidden from the exclusions of each resource.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -104,6 +121,38 @@ func (p *azureadProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.AlwaysExcludedGroupIDs.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("always_excluded_group_ids"),
+			"Unknown always excluded group ids",
+			"The provider cannot apply the always excluded groups as there is an unknown configuration value for the "+
+				"always excluded group ids. Set the value statically in the configuration.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var alwaysExcludedGroupIDs []string
+	if !config.AlwaysExcludedGroupIDs.IsNull() {
+		diags = config.AlwaysExcludedGroupIDs.ElementsAs(ctx, &alwaysExcludedGroupIDs, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	for _, groupID := range alwaysExcludedGroupIDs {
+		if !guidRegex.MatchString(groupID) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("always_excluded_group_ids"),
+				"Invalid always excluded group id",
+				fmt.Sprintf("'%v' is not a valid group object ID.", groupID),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -206,23 +255,46 @@ func (p *azureadProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	// Azuread clients wrapper
 	azureadClients := azureadClients{
-		graphClient: graphClient,
+		graphClient:            graphClient,
+		alwaysExcludedGroupIDs: alwaysExcludedGroupIDs,
 	}
 
-	// Make the MS Graph API client available during DataSource and Resource type
-	// Configure methods.
+	// Make the MS Graph API client available during DataSource, Resource and
+	// EphemeralResource type Configure methods.
 	resp.DataSourceData = azureadClients
 	resp.ResourceData = azureadClients
+	resp.EphemeralResourceData = azureadClients
 }
 
 func (p *azureadProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAuthStrengthsDataSource,
+		NewAuthMethodPoliciesDataSource,
+		NewAuthMethodModesDataSource,
+		NewAuthStrengthUsageDataSource,
 	}
 }
 
 func (p *azureadProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAuthMethodPolicyResource,
+		NewAuthenticationMethodsPolicyResource,
+		NewAuthenticationMethodsPolicySettingsResource,
+		NewAuthStrengthPolicyResource,
+		NewAuthStrengthCombinationConfigurationResource,
+	}
+}
+
+func (p *azureadProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewTemporaryAccessPassEphemeralResource,
+	}
+}
+
+func (p *azureadProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewBuiltinAuthStrengthIDFunction,
+		NewAuthStrengthPathFunction,
+		NewNormalizeAuthCombinationsFunction,
 	}
 }
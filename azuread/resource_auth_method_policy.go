@@ -2,22 +2,41 @@ package azuread
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 
+	abstractions "github.com/microsoft/kiota-abstractions-go"
 	graph "github.com/microsoftgraph/msgraph-sdk-go"
 	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+	graphReports "github.com/microsoftgraph/msgraph-sdk-go/reports"
 )
 
 var (
-	_ resource.Resource              = &authMethodPolicyResource{}
-	_ resource.ResourceWithConfigure = &authMethodPolicyResource{}
+	_ resource.Resource                   = &authMethodPolicyResource{}
+	_ resource.ResourceWithConfigure      = &authMethodPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &authMethodPolicyResource{}
+	_ resource.ResourceWithUpgradeState   = &authMethodPolicyResource{}
+	_ resource.ResourceWithModifyPlan     = &authMethodPolicyResource{}
 )
 
 func NewAuthMethodPolicyResource() resource.Resource {
@@ -25,13 +44,120 @@ func NewAuthMethodPolicyResource() resource.Resource {
 }
 
 type authMethodPolicyResource struct {
-	client *graph.GraphServiceClient
+	client                 *graph.GraphServiceClient
+	alwaysExcludedGroupIDs []string
 }
 
 type authMethodPolicyResourceModel struct {
-	State            types.String   `tfsdk:"state"`
-	Type             types.String   `tfsdk:"type"`
-	ExcludedGroupIDs []types.String `tfsdk:"excluded_group_ids"`
+	State          types.String                   `tfsdk:"state"`
+	Type           types.String                   `tfsdk:"type"`
+	ExcludeTargets []authMethodExcludeTargetModel `tfsdk:"exclude_targets"`
+	IncludeTargets []authMethodIncludeTargetModel `tfsdk:"include_target"`
+	Fido2Settings  *fido2SettingsModel            `tfsdk:"fido2_settings"`
+
+	ExcludedGroupNames   types.Set `tfsdk:"excluded_group_names"`
+	ExcludedGroupNameIDs types.Map `tfsdk:"excluded_group_name_ids"`
+
+	AlwaysExcludedGroupIDs types.Set `tfsdk:"always_excluded_group_ids"`
+
+	LockoutProtection *lockoutProtectionModel `tfsdk:"lockout_protection"`
+
+	MicrosoftAuthenticatorSettings *microsoftAuthenticatorSettingsModel `tfsdk:"microsoft_authenticator_settings"`
+	TemporaryAccessPassSettings    *temporaryAccessPassSettingsModel    `tfsdk:"temporary_access_pass_settings"`
+	X509CertificateSettings        *x509CertificateSettingsModel        `tfsdk:"x509_certificate_settings"`
+	EmailSettings                  *emailSettingsModel                  `tfsdk:"email_settings"`
+	VoiceSettings                  *voiceSettingsModel                  `tfsdk:"voice_settings"`
+	QRCodePinSettings              *qrCodePinSettingsModel              `tfsdk:"qr_code_pin_settings"`
+}
+
+type authMethodExcludeTargetModel struct {
+	ID         types.String `tfsdk:"id"`
+	TargetType types.String `tfsdk:"target_type"`
+}
+
+type authMethodIncludeTargetModel struct {
+	ID                     types.String `tfsdk:"id"`
+	IsRegistrationRequired types.Bool   `tfsdk:"is_registration_required"`
+	AuthenticationMode     types.String `tfsdk:"authentication_mode"`
+	IsUsableForSignIn      types.Bool   `tfsdk:"is_usable_for_sign_in"`
+}
+
+type emailSettingsModel struct {
+	AllowExternalIDToUseEmailOtp types.String `tfsdk:"allow_external_id_to_use_email_otp"`
+}
+
+type voiceSettingsModel struct {
+	IsOfficePhoneAllowed types.Bool `tfsdk:"is_office_phone_allowed"`
+}
+
+type qrCodePinSettingsModel struct {
+	PinLength                    types.Int64 `tfsdk:"pin_length"`
+	StandardQRCodeLifetimeInDays types.Int64 `tfsdk:"standard_qr_code_lifetime_in_days"`
+}
+
+type microsoftAuthenticatorSettingsModel struct {
+	IsSoftwareOathEnabled      types.Bool                 `tfsdk:"is_software_oath_enabled"`
+	DisplayAppInformation      *authenticatorFeatureModel `tfsdk:"display_app_information"`
+	DisplayLocationInformation *authenticatorFeatureModel `tfsdk:"display_location_information"`
+	NumberMatching             *authenticatorFeatureModel `tfsdk:"number_matching"`
+	CompanionApp               *authenticatorFeatureModel `tfsdk:"companion_app"`
+}
+
+type authenticatorFeatureModel struct {
+	State             types.String `tfsdk:"state"`
+	IncludeTargetID   types.String `tfsdk:"include_target_id"`
+	IncludeTargetType types.String `tfsdk:"include_target_type"`
+	ExcludeTargetID   types.String `tfsdk:"exclude_target_id"`
+	ExcludeTargetType types.String `tfsdk:"exclude_target_type"`
+}
+
+type temporaryAccessPassSettingsModel struct {
+	DefaultLifetimeInMinutes types.Int64 `tfsdk:"default_lifetime_in_minutes"`
+	MinimumLifetimeInMinutes types.Int64 `tfsdk:"minimum_lifetime_in_minutes"`
+	MaximumLifetimeInMinutes types.Int64 `tfsdk:"maximum_lifetime_in_minutes"`
+	DefaultLength            types.Int64 `tfsdk:"default_length"`
+	IsUsableOnce             types.Bool  `tfsdk:"is_usable_once"`
+}
+
+type x509CertificateSettingsModel struct {
+	DefaultAuthenticationMode    types.String                      `tfsdk:"default_authentication_mode"`
+	DefaultRequiredAffinityLevel types.String                      `tfsdk:"default_required_affinity_level"`
+	UserBindings                 []x509CertificateUserBindingModel `tfsdk:"user_binding"`
+	Rules                        []x509CertificateRuleModel        `tfsdk:"rule"`
+}
+
+type x509CertificateUserBindingModel struct {
+	Priority             types.Int64  `tfsdk:"priority"`
+	X509CertificateField types.String `tfsdk:"x509_certificate_field"`
+	UserProperty         types.String `tfsdk:"user_property"`
+	TrustAffinityLevel   types.String `tfsdk:"trust_affinity_level"`
+}
+
+type x509CertificateRuleModel struct {
+	Type                    types.String `tfsdk:"type"`
+	Identifier              types.String `tfsdk:"identifier"`
+	IssuerSubjectIdentifier types.String `tfsdk:"issuer_subject_identifier"`
+	PolicyOidIdentifier     types.String `tfsdk:"policy_oid_identifier"`
+	AuthenticationMode      types.String `tfsdk:"authentication_mode"`
+	RequiredAffinityLevel   types.String `tfsdk:"required_affinity_level"`
+}
+
+type fido2SettingsModel struct {
+	IsSelfServiceRegistrationAllowed types.Bool                 `tfsdk:"is_self_service_registration_allowed"`
+	IsAttestationEnforced            types.Bool                 `tfsdk:"is_attestation_enforced"`
+	KeyRestrictions                  *fido2KeyRestrictionsModel `tfsdk:"key_restrictions"`
+}
+
+type fido2KeyRestrictionsModel struct {
+	IsEnforced      types.Bool     `tfsdk:"is_enforced"`
+	EnforcementType types.String   `tfsdk:"enforcement_type"`
+	AaGuids         []types.String `tfsdk:"aaguids"`
+}
+
+type lockoutProtectionModel struct {
+	MaxStrandedUsers  types.Int64    `tfsdk:"max_stranded_users"`
+	ProtectedGroupIDs []types.String `tfsdk:"protected_group_ids"`
+	Force             types.Bool     `tfsdk:"force"`
 }
 
 func (r *authMethodPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -40,8 +166,10 @@ func (r *authMethodPolicyResource) Metadata(_ context.Context, req resource.Meta
 
 func (r *authMethodPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages an authentication method policy on Microsoft Entra ID. Currently, " +
-			" QR code and Hardware OATH Tokens are not supported in Microsoft Graph API",
+		Version: 1,
+		Description: "Manages an authentication method policy on Microsoft Entra ID. QR code and " +
+			"Hardware OATH tokens are managed through the Microsoft Graph API beta endpoint, as they " +
+			"are not available in the v1.0 endpoint.",
 		Attributes: map[string]schema.Attribute{
 			"state": schema.StringAttribute{
 				Description: "Whether the authentication method policy is enabled in the tenant. " +
@@ -50,15 +178,441 @@ func (r *authMethodPolicyResource) Schema(_ context.Context, _ resource.SchemaRe
 			},
 			"type": schema.StringAttribute{
 				Description: "The type of the authentication method policy. Possible values are " +
-					"`Email`, `Fido2`, `MicrosoftAuthenticator`, `Voice`, `Sms`, `SoftwareOath`" +
-					"`TemporaryAccessPass`, `X509Certificate`",
+					"`Email`, `Fido2`, `MicrosoftAuthenticator`, `Voice`, `Sms`, `SoftwareOath`, " +
+					"`TemporaryAccessPass`, `X509Certificate`, `HardwareOath`, `QRCodePin`",
 				Required: true,
 			},
-			"excluded_group_ids": schema.ListAttribute{
-				Description: "A list of group IDs to exclude from the authentication method policy.",
+			"exclude_targets": authMethodExcludeTargetsAttribute(),
+			"excluded_group_names": schema.SetAttribute{
+				Description: "A set of display names or mail nicknames of groups to exclude from the " +
+					"authentication method policy. Each name must match exactly one group.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"excluded_group_name_ids": schema.MapAttribute{
+				Description: "The group IDs resolved from `excluded_group_names`, keyed by name.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"always_excluded_group_ids": schema.SetAttribute{
+				Description: "The group IDs from the provider `always_excluded_group_ids` that are excluded " +
+					"from the authentication method policy.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"include_target": authMethodIncludeTargetBlock(),
+			"email_settings": schema.SingleNestedBlock{
+				Description: "The settings of the Email OTP authentication method. Only applicable " +
+					"when `type` is `Email`. If not configured, the settings on Microsoft Entra ID " +
+					"are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"allow_external_id_to_use_email_otp": schema.StringAttribute{
+						Description: "Whether external users may use Email OTP to sign in. Possible " +
+							"values are `default`, `enabled` or `disabled`. Defaults to `default`.",
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString("default"),
+					},
+				},
+			},
+			"qr_code_pin_settings": schema.SingleNestedBlock{
+				Description: "The settings of the QR code authentication method. Only applicable " +
+					"when `type` is `QRCodePin`. If not configured, the settings on Microsoft Entra " +
+					"ID are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"pin_length": schema.Int64Attribute{
+						Description: fmt.Sprintf("The length of the PIN used with the QR code, between "+
+							"`%d` and `%d`. Defaults to `%d`.", qrCodeMinPinLength, qrCodeMaxPinLength,
+							qrCodeDefaultPinLength),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(qrCodeDefaultPinLength),
+					},
+					"standard_qr_code_lifetime_in_days": schema.Int64Attribute{
+						Description: fmt.Sprintf("The lifetime of a standard QR code in days, between "+
+							"`%d` and `%d`. Defaults to `%d`.", qrCodeMinLifetimeInDays, qrCodeMaxLifetimeInDays,
+							qrCodeDefaultLifetimeInDays),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(qrCodeDefaultLifetimeInDays),
+					},
+				},
+			},
+			"voice_settings": schema.SingleNestedBlock{
+				Description: "The settings of the Voice call authentication method. Only applicable " +
+					"when `type` is `Voice`. If not configured, the settings on Microsoft Entra ID " +
+					"are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"is_office_phone_allowed": schema.BoolAttribute{
+						Description: "Whether users may use their office phone for voice calls. " +
+							"Defaults to `false`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
+				},
+			},
+			"fido2_settings": schema.SingleNestedBlock{
+				Description: "The settings of the passkey (FIDO2) authentication method. Only " +
+					"applicable when `type` is `Fido2`. If not configured, the settings on " +
+					"Microsoft Entra ID are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"is_self_service_registration_allowed": schema.BoolAttribute{
+						Description: "Whether users are allowed to register a passkey (FIDO2) " +
+							"through self-service. Defaults to `true`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(true),
+					},
+					"is_attestation_enforced": schema.BoolAttribute{
+						Description: "Whether the passkey (FIDO2) must provide an attestation " +
+							"during registration. Defaults to `false`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"key_restrictions": schema.SingleNestedBlock{
+						Description: "Restricts the passkeys (FIDO2) that may be registered by " +
+							"their AAGUID. If not configured, the key restrictions on Microsoft " +
+							"Entra ID are left unchanged.",
+						Attributes: map[string]schema.Attribute{
+							"is_enforced": schema.BoolAttribute{
+								Description: "Whether the key restrictions are enforced. Defaults to `false`.",
+								Optional:    true,
+								Computed:    true,
+								Default:     booldefault.StaticBool(false),
+							},
+							"enforcement_type": schema.StringAttribute{
+								Description: "Whether the listed AAGUIDs are allowed or blocked. " +
+									"Possible values are `allow` or `block`. Defaults to `block`.",
+								Optional: true,
+								Computed: true,
+								Default:  stringdefault.StaticString("block"),
+							},
+							"aaguids": schema.SetAttribute{
+								Description: "A set of passkey (FIDO2) AAGUIDs to allow or block.",
+								Optional:    true,
+								ElementType: types.StringType,
+							},
+						},
+					},
+				},
+			},
+			"microsoft_authenticator_settings": schema.SingleNestedBlock{
+				Description: "The settings of the Microsoft Authenticator authentication method. " +
+					"Only applicable when `type` is `MicrosoftAuthenticator`. If not configured, " +
+					"the settings on Microsoft Entra ID are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"is_software_oath_enabled": schema.BoolAttribute{
+						Description: "Whether users may use the OTP code generated by the Microsoft " +
+							"Authenticator app. Defaults to `false`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"display_app_information": authenticatorFeatureBlock(
+						"Whether the name of the application requesting the authentication is " +
+							"shown in the Microsoft Authenticator notification."),
+					"display_location_information": authenticatorFeatureBlock(
+						"Whether the geographic location of the sign in is shown in the " +
+							"Microsoft Authenticator notification."),
+					"number_matching": authenticatorFeatureBlock(
+						"Whether number matching is required in the Microsoft Authenticator " +
+							"notification. Only part of the Microsoft Graph API beta schema, so the " +
+							"Microsoft Authenticator settings are managed through the beta endpoint."),
+					"companion_app": authenticatorFeatureBlock(
+						"Whether the Microsoft Authenticator companion applications, such as " +
+							"Outlook, may be used to approve notifications. Only part of the Microsoft " +
+							"Graph API beta schema, so the Microsoft Authenticator settings are managed " +
+							"through the beta endpoint."),
+				},
+			},
+			"temporary_access_pass_settings": schema.SingleNestedBlock{
+				Description: "The settings of the Temporary Access Pass authentication method. " +
+					"Only applicable when `type` is `TemporaryAccessPass`. If not configured, " +
+					"the settings on Microsoft Entra ID are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"default_lifetime_in_minutes": schema.Int64Attribute{
+						Description: fmt.Sprintf("The default lifetime of a Temporary Access Pass in minutes, "+
+							"between `%d` and `%d`. Defaults to `%d`.", tapMinLifetimeInMinutes, tapMaxLifetimeInMinutes,
+							tapDefaultLifetimeInMinutes),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(tapDefaultLifetimeInMinutes),
+					},
+					"minimum_lifetime_in_minutes": schema.Int64Attribute{
+						Description: fmt.Sprintf("The minimum lifetime of a Temporary Access Pass in minutes, "+
+							"between `%d` and `%d`. Defaults to `%d`.", tapMinLifetimeInMinutes, tapMaxLifetimeInMinutes,
+							tapDefaultMinimumLifetimeInMinutes),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(tapDefaultMinimumLifetimeInMinutes),
+					},
+					"maximum_lifetime_in_minutes": schema.Int64Attribute{
+						Description: fmt.Sprintf("The maximum lifetime of a Temporary Access Pass in minutes, "+
+							"between `%d` and `%d`. Defaults to `%d`.", tapMinLifetimeInMinutes, tapMaxLifetimeInMinutes,
+							tapDefaultMaximumLifetimeInMinutes),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(tapDefaultMaximumLifetimeInMinutes),
+					},
+					"default_length": schema.Int64Attribute{
+						Description: fmt.Sprintf("The default length of a Temporary Access Pass, between "+
+							"`%d` and `%d` characters. Defaults to `%d`.", tapMinLength, tapMaxLength, tapDefaultLength),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(tapDefaultLength),
+					},
+					"is_usable_once": schema.BoolAttribute{
+						Description: "Whether a Temporary Access Pass can only be used once. Defaults to `false`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+				},
+			},
+			"x509_certificate_settings": schema.SingleNestedBlock{
+				Description: "The settings of the certificate-based authentication method. Only " +
+					"applicable when `type` is `X509Certificate`. If not configured, the settings " +
+					"on Microsoft Entra ID are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"default_authentication_mode": schema.StringAttribute{
+						Description: "The authentication strength of certificates not matching any rule. " +
+							"Possible values are `x509CertificateSingleFactor` or `x509CertificateMultiFactor`. " +
+							"Defaults to `x509CertificateSingleFactor`.",
+						Optional: true,
+						Computed: true,
+						Default: stringdefault.StaticString(
+							graphModels.X509CERTIFICATESINGLEFACTOR_X509CERTIFICATEAUTHENTICATIONMODE.String()),
+					},
+					"default_required_affinity_level": schema.StringAttribute{
+						Description: "The affinity level required for certificates not matching any " +
+							"rule. Possible values are `low` or `high`. Defaults to `low`.",
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString("low"),
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"user_binding": schema.ListNestedBlock{
+						Description: "Maps a certificate field to a user property to identify the " +
+							"signing in user. If no user binding is configured, the user bindings on " +
+							"Microsoft Entra ID are left unchanged.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"priority": schema.Int64Attribute{
+									Description: "The priority of the binding, lower values are evaluated first.",
+									Required:    true,
+								},
+								"x509_certificate_field": schema.StringAttribute{
+									Description: "The certificate field to match. Possible values are " +
+										"`PrincipalName`, `RFC822Name`, `SubjectKeyIdentifier`, `SHA1PublicKey`, " +
+										"`IssuerAndSubject`, `Subject` or `IssuerAndSerialNumber`.",
+									Required: true,
+								},
+								"user_property": schema.StringAttribute{
+									Description: "The user property to match against. Possible values are " +
+										"`userPrincipalName`, `onPremisesUserPrincipalName` or `certificateUserIds`.",
+									Required: true,
+								},
+								"trust_affinity_level": schema.StringAttribute{
+									Description: "The affinity level of the binding. Possible values are `low` " +
+										"or `high`. Defaults to `low`.",
+									Optional: true,
+									Computed: true,
+									Default:  stringdefault.StaticString("low"),
+								},
+							},
+						},
+					},
+					"rule": schema.ListNestedBlock{
+						Description: "Maps certificates of an issuer or policy OID to an authentication " +
+							"strength. Rules on Microsoft Entra ID not configured here are removed.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									Description: "The type of the rule. Possible values are `issuerSubject`, " +
+										"`policyOID` or `issuerSubjectAndPolicyOID`.",
+									Required: true,
+								},
+								"identifier": schema.StringAttribute{
+									Description: "The issuer subject or policy OID to match. Required when " +
+										"`type` is `issuerSubject` or `policyOID`.",
+									Optional: true,
+								},
+								"issuer_subject_identifier": schema.StringAttribute{
+									Description: "The issuer subject to match. Required when `type` is " +
+										"`issuerSubjectAndPolicyOID`.",
+									Optional: true,
+								},
+								"policy_oid_identifier": schema.StringAttribute{
+									Description: "The policy OID to match. Required when `type` is " +
+										"`issuerSubjectAndPolicyOID`.",
+									Optional: true,
+								},
+								"authentication_mode": schema.StringAttribute{
+									Description: "The authentication strength of the matching certificates. " +
+										"Possible values are `x509CertificateSingleFactor` or " +
+										"`x509CertificateMultiFactor`.",
+									Required: true,
+								},
+								"required_affinity_level": schema.StringAttribute{
+									Description: "The affinity level required for the matching certificates. " +
+										"Possible values are `low` or `high`. Defaults to `low`.",
+									Optional: true,
+									Computed: true,
+									Default:  stringdefault.StaticString("low"),
+								},
+							},
+						},
+					},
+				},
+			},
+			"lockout_protection": schema.SingleNestedBlock{
+				Description: "Checks the authentication methods registered by the users before " +
+					"disabling the authentication method or excluding more users from it, and " +
+					"refuses the change if it would leave users without any usable authentication " +
+					"method. Another registered method is only usable if it is enabled and targets " +
+					"the user. If not configured, no check is done.",
+				Attributes: map[string]schema.Attribute{
+					"max_stranded_users": schema.Int64Attribute{
+						Description: "The maximum number of users that may be left without any " +
+							"usable authentication method. Defaults to `0`.",
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(0),
+					},
+					"protected_group_ids": schema.SetAttribute{
+						Description: "A set of group IDs whose members must never be left without " +
+							"any usable authentication method, regardless of `max_stranded_users`.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"force": schema.BoolAttribute{
+						Description: "Whether to apply the change even if it would leave users " +
+							"without any usable authentication method. Defaults to `false`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
+				},
+			},
+		},
+	}
+}
+
+// authMethodExcludeTargetsAttribute is the schema of the users or groups
+// excluded from an authentication method.
+func authMethodExcludeTargetsAttribute() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Description: "A set of users or groups to exclude from the authentication method policy.",
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Description: "The object ID of the user or group to exclude.",
+					Required:    true,
+				},
+				"target_type": schema.StringAttribute{
+					Description: "The type of the excluded target. Possible values are `group` or `user`.",
+					Required:    true,
+				},
+			},
+		},
+	}
+}
+
+// authMethodIncludeTargetBlock is the schema of the users or groups an
+// authentication method applies to.
+func authMethodIncludeTargetBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "The users or groups the authentication method policy applies to. " +
+			"If no include target is configured, the include targets on Microsoft Entra ID " +
+			"are left unchanged.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Description: "The object ID of the group to include, or `all_users` to " +
+						"include every user in the tenant.",
+					Required: true,
+				},
+				"is_registration_required": schema.BoolAttribute{
+					Description: "Whether the targeted users are required to register the " +
+						"authentication method. Defaults to `false`.",
+					Optional: true,
+					Computed: true,
+					Default:  booldefault.StaticBool(false),
+				},
+				"authentication_mode": schema.StringAttribute{
+					Description: "The authentication mode allowed for the targeted users. Only " +
+						"applicable when `type` is `MicrosoftAuthenticator`. Possible values are " +
+						"`any`, `push` or `deviceBasedPush`. Defaults to `any`.",
+					Optional: true,
+					Computed: true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+				},
+				"is_usable_for_sign_in": schema.BoolAttribute{
+					Description: "Whether the targeted users may use SMS to sign in, rather " +
+						"than only as a second factor. Only applicable when `type` is `Sms`. " +
+						"Defaults to `true`.",
+					Optional: true,
+					Computed: true,
+					PlanModifiers: []planmodifier.Bool{
+						boolplanmodifier.UseStateForUnknown(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func authenticatorFeatureBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description + " If not configured, the feature setting on Microsoft " +
+			"Entra ID is left unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"state": schema.StringAttribute{
+				Description: "The state of the feature. Possible values are `default`, " +
+					"`enabled` or `disabled`. Defaults to `default`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("default"),
+			},
+			"include_target_id": schema.StringAttribute{
+				Description: "The ID of the target the feature applies to. Defaults to `all_users`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(allUsersTargetID),
+			},
+			"include_target_type": schema.StringAttribute{
+				Description: "The type of the include target. Possible values are `group`, " +
+					"`role` or `administrativeUnit`. Defaults to `group`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("group"),
+			},
+			"exclude_target_id": schema.StringAttribute{
+				Description: "The ID of the target excluded from the feature. Defaults to " +
+					"`00000000-0000-0000-0000-000000000000`, which excludes no one.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(noneTargetID),
+			},
+			"exclude_target_type": schema.StringAttribute{
+				Description: "The type of the exclude target. Possible values are `group`, " +
+					"`role` or `administrativeUnit`. Defaults to `group`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("group"),
+			},
 		},
 	}
 }
@@ -69,6 +623,406 @@ func (r *authMethodPolicyResource) Configure(_ context.Context, req resource.Con
 	}
 
 	r.client = req.ProviderData.(azureadClients).graphClient
+	r.alwaysExcludedGroupIDs = req.ProviderData.(azureadClients).alwaysExcludedGroupIDs
+}
+
+func (r *authMethodPolicyResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 excluded groups through the 'excluded_group_ids' list.
+		0: {
+			StateUpgrader: upgradeAuthMethodPolicyStateV0,
+		},
+	}
+}
+
+func upgradeAuthMethodPolicyStateV0(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var rawState map[string]json.RawMessage
+	if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+		resp.Diagnostics.AddError(
+			"[STATE ERROR] Unable to Upgrade Authentication Method Policy State",
+			"An unexpected error occurred while reading the prior state of the "+
+				"Authentication Method Policy.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	var excludedGroupIDs []string
+	if err := json.Unmarshal(rawState["excluded_group_ids"], &excludedGroupIDs); rawState["excluded_group_ids"] != nil && err != nil {
+		resp.Diagnostics.AddError(
+			"[STATE ERROR] Unable to Upgrade Authentication Method Policy State",
+			"An unexpected error occurred while reading 'excluded_group_ids' from the prior "+
+				"state of the Authentication Method Policy.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+	delete(rawState, "excluded_group_ids")
+
+	var excludeTargets []map[string]string
+	for _, excludedGroupID := range excludedGroupIDs {
+		excludeTargets = append(excludeTargets, map[string]string{
+			"id":          excludedGroupID,
+			"target_type": graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE.String(),
+		})
+	}
+	rawState["exclude_targets"], _ = json.Marshal(excludeTargets)
+
+	// List blocks are never null.
+	if rawState["include_target"] == nil || string(rawState["include_target"]) == "null" {
+		rawState["include_target"] = json.RawMessage("[]")
+	}
+
+	upgradedState, err := json.Marshal(rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[STATE ERROR] Unable to Upgrade Authentication Method Policy State",
+			"An unexpected error occurred while writing the upgraded state of the "+
+				"Authentication Method Policy.\n\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{
+		JSON: upgradedState,
+	}
+}
+
+// ModifyPlan resolves the excluded group names to their IDs, so that a name
+// matching no group or more than one group fails during plan, and plans the
+// provider-wide excluded groups.
+func (r *authMethodPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan authMethodPolicyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupNames := []string{}
+	isKnown := !plan.ExcludedGroupNames.IsUnknown()
+	for _, element := range plan.ExcludedGroupNames.Elements() {
+		name, ok := element.(types.String)
+		if !ok || name.IsUnknown() {
+			isKnown = false
+			break
+		}
+		groupNames = append(groupNames, name.ValueString())
+	}
+
+	if plan.ExcludedGroupNames.IsNull() {
+		plan.ExcludedGroupNameIDs = types.MapNull(types.StringType)
+	} else if !isKnown || r.client == nil {
+		plan.ExcludedGroupNameIDs = types.MapUnknown(types.StringType)
+	} else {
+		groupIDs, resolveDiags := resolveGroupNames(r.client, groupNames)
+		resp.Diagnostics.Append(resolveDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var mapDiags diag.Diagnostics
+		plan.ExcludedGroupNameIDs, mapDiags = types.MapValueFrom(ctx, types.StringType, groupIDs)
+		resp.Diagnostics.Append(mapDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	setPlanDiags := resp.Plan.SetAttribute(ctx, path.Root("excluded_group_name_ids"), plan.ExcludedGroupNameIDs)
+	resp.Diagnostics.Append(setPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alwaysExcludedGroupIDs := types.SetNull(types.StringType)
+	if len(r.alwaysExcludedGroupIDs) > 0 {
+		var setDiags diag.Diagnostics
+		alwaysExcludedGroupIDs, setDiags = types.SetValueFrom(ctx, types.StringType, r.alwaysExcludedGroupIDs)
+		resp.Diagnostics.Append(setDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	setPlanDiags = resp.Plan.SetAttribute(ctx, path.Root("always_excluded_group_ids"), alwaysExcludedGroupIDs)
+	resp.Diagnostics.Append(setPlanDiags...)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// Removing a provider-wide excluded group from 'exclude_targets' does not
+	// remove its exclusion.
+	var state authMethodPolicyResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, target := range state.ExcludeTargets {
+		isDeclared := slices.ContainsFunc(plan.ExcludeTargets, func(planTarget authMethodExcludeTargetModel) bool {
+			return planTarget.ID.Equal(target.ID)
+		})
+		if !isDeclared && slices.Contains(r.alwaysExcludedGroupIDs, target.ID.ValueString()) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("exclude_targets"),
+				"Provider-wide Excluded Group",
+				fmt.Sprintf("The group '%v' is removed from 'exclude_targets' but stays excluded from the "+
+					"authentication method policy, as it is set in the provider 'always_excluded_group_ids'.",
+					target.ID.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *authMethodPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config authMethodPolicyResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.LockoutProtection != nil {
+		if !config.LockoutProtection.MaxStrandedUsers.IsNull() && !config.LockoutProtection.MaxStrandedUsers.IsUnknown() &&
+			config.LockoutProtection.MaxStrandedUsers.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lockout_protection").AtName("max_stranded_users"),
+				"[INPUT ERROR] Invalid Maximum Stranded Users",
+				fmt.Sprintf("'%d' is invalid, the maximum number of stranded users may not be negative.",
+					config.LockoutProtection.MaxStrandedUsers.ValueInt64()),
+			)
+		}
+
+		for _, groupID := range config.LockoutProtection.ProtectedGroupIDs {
+			if !groupID.IsNull() && !groupID.IsUnknown() && !guidRegex.MatchString(groupID.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("lockout_protection").AtName("protected_group_ids"),
+					"[INPUT ERROR] Invalid Protected Group ID",
+					fmt.Sprintf("'%v' is not a valid group object ID.", groupID.ValueString()),
+				)
+			}
+		}
+	}
+
+	if config.Type.IsUnknown() {
+		return
+	}
+
+	// Type specific settings blocks and the authentication method type they
+	// apply to.
+	settingsBlocks := []struct {
+		name           string
+		authMethodType string
+		isSet          bool
+	}{
+		{"fido2_settings", "Fido2", config.Fido2Settings != nil},
+		{"microsoft_authenticator_settings", "MicrosoftAuthenticator", config.MicrosoftAuthenticatorSettings != nil},
+		{"temporary_access_pass_settings", "TemporaryAccessPass", config.TemporaryAccessPassSettings != nil},
+		{"x509_certificate_settings", "X509Certificate", config.X509CertificateSettings != nil},
+		{"email_settings", "Email", config.EmailSettings != nil},
+		{"voice_settings", "Voice", config.VoiceSettings != nil},
+		{"qr_code_pin_settings", "QRCodePin", config.QRCodePinSettings != nil},
+	}
+
+	for _, settings := range settingsBlocks {
+		if settings.isSet && config.Type.ValueString() != settings.authMethodType {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(settings.name),
+				"[INPUT ERROR] Invalid Authentication Method Settings",
+				fmt.Sprintf("'%v' is only supported when 'type' is '%v', got '%v'.",
+					settings.name, settings.authMethodType, config.Type.ValueString()),
+			)
+		}
+	}
+
+	if settings := config.MicrosoftAuthenticatorSettings; settings != nil {
+		features := map[string]*authenticatorFeatureModel{
+			"display_app_information":      settings.DisplayAppInformation,
+			"display_location_information": settings.DisplayLocationInformation,
+			"number_matching":              settings.NumberMatching,
+			"companion_app":                settings.CompanionApp,
+		}
+
+		for name, feature := range features {
+			if feature == nil {
+				continue
+			}
+			featurePath := path.Root("microsoft_authenticator_settings").AtName(name)
+
+			if !feature.State.IsNull() && !feature.State.IsUnknown() {
+				if state, _ := graphModels.ParseAdvancedConfigState(feature.State.ValueString()); state == nil {
+					resp.Diagnostics.AddAttributeError(
+						featurePath.AtName("state"),
+						"[INPUT ERROR] Invalid Feature Setting",
+						fmt.Sprintf("'%v' is invalid, only acceptable values are 'default', 'enabled' and 'disabled'.",
+							feature.State.ValueString()),
+					)
+				}
+			}
+
+			for attrName, targetType := range map[string]types.String{
+				"include_target_type": feature.IncludeTargetType,
+				"exclude_target_type": feature.ExcludeTargetType,
+			} {
+				if targetType.IsNull() || targetType.IsUnknown() {
+					continue
+				}
+				if parsed, _ := graphModels.ParseFeatureTargetType(targetType.ValueString()); parsed == nil {
+					resp.Diagnostics.AddAttributeError(
+						featurePath.AtName(attrName),
+						"[INPUT ERROR] Invalid Feature Setting",
+						fmt.Sprintf("'%v' is invalid, only acceptable values are 'group', 'role' and 'administrativeUnit'.",
+							targetType.ValueString()),
+					)
+				}
+			}
+		}
+	}
+
+	if settings := config.TemporaryAccessPassSettings; settings != nil {
+		settingsPath := path.Root("temporary_access_pass_settings")
+
+		ranges := []struct {
+			name     string
+			value    types.Int64
+			min, max int64
+		}{
+			{"default_lifetime_in_minutes", settings.DefaultLifetimeInMinutes, tapMinLifetimeInMinutes, tapMaxLifetimeInMinutes},
+			{"minimum_lifetime_in_minutes", settings.MinimumLifetimeInMinutes, tapMinLifetimeInMinutes, tapMaxLifetimeInMinutes},
+			{"maximum_lifetime_in_minutes", settings.MaximumLifetimeInMinutes, tapMinLifetimeInMinutes, tapMaxLifetimeInMinutes},
+			{"default_length", settings.DefaultLength, tapMinLength, tapMaxLength},
+		}
+
+		for _, r := range ranges {
+			if r.value.IsNull() || r.value.IsUnknown() {
+				continue
+			}
+			if r.value.ValueInt64() < r.min || r.value.ValueInt64() > r.max {
+				resp.Diagnostics.AddAttributeError(
+					settingsPath.AtName(r.name),
+					"[INPUT ERROR] Invalid Temporary Access Pass Settings",
+					fmt.Sprintf("'%v' must be between %d and %d, got %d.",
+						r.name, r.min, r.max, r.value.ValueInt64()),
+				)
+			}
+		}
+
+		// Omitted lifetimes are compared using their schema defaults, unknown
+		// lifetimes are left for Graph API to validate.
+		lifetime := func(value types.Int64, defaultValue int64) (int64, bool) {
+			if value.IsUnknown() {
+				return 0, false
+			}
+			if value.IsNull() {
+				return defaultValue, true
+			}
+			return value.ValueInt64(), true
+		}
+
+		defaultLifetime, defaultKnown := lifetime(settings.DefaultLifetimeInMinutes, tapDefaultLifetimeInMinutes)
+		minLifetime, minKnown := lifetime(settings.MinimumLifetimeInMinutes, tapDefaultMinimumLifetimeInMinutes)
+		maxLifetime, maxKnown := lifetime(settings.MaximumLifetimeInMinutes, tapDefaultMaximumLifetimeInMinutes)
+
+		if minKnown && maxKnown && minLifetime > maxLifetime {
+			resp.Diagnostics.AddAttributeError(
+				settingsPath.AtName("minimum_lifetime_in_minutes"),
+				"[INPUT ERROR] Invalid Temporary Access Pass Settings",
+				fmt.Sprintf("'minimum_lifetime_in_minutes' (%d) must not be greater than "+
+					"'maximum_lifetime_in_minutes' (%d).", minLifetime, maxLifetime),
+			)
+		}
+		if defaultKnown && (minKnown && defaultLifetime < minLifetime || maxKnown && defaultLifetime > maxLifetime) {
+			resp.Diagnostics.AddAttributeError(
+				settingsPath.AtName("default_lifetime_in_minutes"),
+				"[INPUT ERROR] Invalid Temporary Access Pass Settings",
+				fmt.Sprintf("'default_lifetime_in_minutes' (%d) must be between "+
+					"'minimum_lifetime_in_minutes' (%d) and 'maximum_lifetime_in_minutes' (%d).",
+					defaultLifetime, minLifetime, maxLifetime),
+			)
+		}
+	}
+
+	if settings := config.X509CertificateSettings; settings != nil {
+		resp.Diagnostics.Append(validateX509CertificateSettings(settings)...)
+	}
+
+	if settings := config.QRCodePinSettings; settings != nil {
+		ranges := []struct {
+			name     string
+			value    types.Int64
+			min, max int64
+		}{
+			{"pin_length", settings.PinLength, qrCodeMinPinLength, qrCodeMaxPinLength},
+			{"standard_qr_code_lifetime_in_days", settings.StandardQRCodeLifetimeInDays, qrCodeMinLifetimeInDays, qrCodeMaxLifetimeInDays},
+		}
+
+		for _, r := range ranges {
+			if r.value.IsNull() || r.value.IsUnknown() {
+				continue
+			}
+			if r.value.ValueInt64() < r.min || r.value.ValueInt64() > r.max {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("qr_code_pin_settings").AtName(r.name),
+					"[INPUT ERROR] Invalid QR Code Settings",
+					fmt.Sprintf("'%v' must be between %d and %d, got %d.",
+						r.name, r.min, r.max, r.value.ValueInt64()),
+				)
+			}
+		}
+	}
+
+	if settings := config.EmailSettings; settings != nil &&
+		!settings.AllowExternalIDToUseEmailOtp.IsNull() && !settings.AllowExternalIDToUseEmailOtp.IsUnknown() {
+		if state, _ := graphModels.ParseExternalEmailOtpState(settings.AllowExternalIDToUseEmailOtp.ValueString()); state == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email_settings").AtName("allow_external_id_to_use_email_otp"),
+				"[INPUT ERROR] Invalid Email Settings",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'default', 'enabled' and 'disabled'.",
+					settings.AllowExternalIDToUseEmailOtp.ValueString()),
+			)
+		}
+	}
+
+	if config.Fido2Settings != nil && config.Fido2Settings.KeyRestrictions != nil {
+		keyRestrictions := config.Fido2Settings.KeyRestrictions
+		keyRestrictionsPath := path.Root("fido2_settings").AtName("key_restrictions")
+
+		if !keyRestrictions.EnforcementType.IsNull() && !keyRestrictions.EnforcementType.IsUnknown() {
+			switch keyRestrictions.EnforcementType.ValueString() {
+			case "allow", "block":
+			default:
+				resp.Diagnostics.AddAttributeError(
+					keyRestrictionsPath.AtName("enforcement_type"),
+					"[INPUT ERROR] Invalid Key Restrictions",
+					fmt.Sprintf("'%v' is invalid, only acceptable values are 'allow' and 'block'.",
+						keyRestrictions.EnforcementType.ValueString()),
+				)
+			}
+		}
+
+		for _, aaGuid := range keyRestrictions.AaGuids {
+			if aaGuid.IsNull() || aaGuid.IsUnknown() {
+				continue
+			}
+			if !guidRegex.MatchString(aaGuid.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					keyRestrictionsPath.AtName("aaguids"),
+					"[INPUT ERROR] Invalid Key Restrictions",
+					fmt.Sprintf("'%v' is not a valid AAGUID.", aaGuid.ValueString()),
+				)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(validateAuthMethodTargets(config.Type.ValueString(), config.ExcludeTargets,
+		config.IncludeTargets, path.Root("exclude_targets"), path.Root("include_target"))...)
 }
 
 // Will overwrite existing excluded groups
@@ -80,6 +1034,12 @@ func (r *authMethodPolicyResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	lockoutDiags := r.checkLockout(ctx, &plan)
+	resp.Diagnostics.Append(lockoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createDiags := r.createAuthMethodPolicy(&plan, &state)
 	resp.Diagnostics.Append(createDiags...)
 	if resp.Diagnostics.HasError() {
@@ -104,11 +1064,7 @@ func (r *authMethodPolicyResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	getAuthMethodPolicy := func() error {
-		authenticationMethodConfigurations, err = r.client.Policies().
-			AuthenticationMethodsPolicy().
-			AuthenticationMethodConfigurations().
-			ByAuthenticationMethodConfigurationId(state.Type.ValueString()).
-			Get(context.Background(), nil)
+		authenticationMethodConfigurations, err = r.getAuthMethodConfiguration(state.Type.ValueString())
 
 		if err != nil {
 			return handleAPIError(err)
@@ -132,18 +1088,93 @@ func (r *authMethodPolicyResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	var excludedGroupIDs []types.String
+	excludeTargets := getExcludeTargets(authenticationMethodConfigurations)
 
-	excludeTargets := authenticationMethodConfigurations.GetExcludeTargets()
+	excludedIDs := map[string]bool{}
 	for _, target := range excludeTargets {
-		if target.GetId() != nil {
-			excludedGroupIDs = append(excludedGroupIDs, types.StringValue(*target.GetId()))
+		excludedIDs[target.ID.ValueString()] = true
+	}
+
+	// Exclusions added through the excluded group names or the provider-wide
+	// excluded groups are reported in their own attributes, instead of
+	// 'exclude_targets', unless they are also declared there.
+	hiddenIDs := map[string]bool{}
+
+	// A name whose group is no longer excluded is dropped, so that it shows
+	// as drift.
+	if !state.ExcludedGroupNameIDs.IsNull() && !state.ExcludedGroupNameIDs.IsUnknown() {
+		var groupNameIDs map[string]string
+		resp.Diagnostics.Append(state.ExcludedGroupNameIDs.ElementsAs(ctx, &groupNameIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for name, id := range groupNameIDs {
+			if !excludedIDs[id] {
+				delete(groupNameIDs, name)
+				continue
+			}
+			hiddenIDs[id] = true
+		}
+
+		var mapDiags diag.Diagnostics
+		state.ExcludedGroupNameIDs, mapDiags = types.MapValueFrom(ctx, types.StringType, groupNameIDs)
+		resp.Diagnostics.Append(mapDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Likewise a provider-wide excluded group that is no longer excluded is
+	// dropped, so that the next apply excludes it again.
+	if len(r.alwaysExcludedGroupIDs) > 0 {
+		alwaysExcludedGroupIDs := []string{}
+		for _, groupID := range r.alwaysExcludedGroupIDs {
+			if excludedIDs[groupID] {
+				alwaysExcludedGroupIDs = append(alwaysExcludedGroupIDs, groupID)
+				hiddenIDs[groupID] = true
+			}
+		}
+
+		var setDiags diag.Diagnostics
+		state.AlwaysExcludedGroupIDs, setDiags = types.SetValueFrom(ctx, types.StringType, alwaysExcludedGroupIDs)
+		resp.Diagnostics.Append(setDiags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+	} else {
+		state.AlwaysExcludedGroupIDs = types.SetNull(types.StringType)
+	}
+
+	if len(hiddenIDs) > 0 {
+		excludeTargets = slices.DeleteFunc(excludeTargets, func(target authMethodExcludeTargetModel) bool {
+			return hiddenIDs[target.ID.ValueString()] && !slices.ContainsFunc(state.ExcludeTargets,
+				func(stateTarget authMethodExcludeTargetModel) bool {
+					return stateTarget.ID.Equal(target.ID)
+				})
+		})
+		if len(excludeTargets) == 0 && state.ExcludeTargets == nil {
+			excludeTargets = nil
+		}
+	}
+
+	// Keep an explicitly empty set of exclusions from showing as drift.
+	if excludeTargets == nil && state.ExcludeTargets != nil {
+		excludeTargets = []authMethodExcludeTargetModel{}
 	}
 
 	state.Type = types.StringValue(*authenticationMethodConfigurations.GetId())
 	state.State = types.StringValue(authenticationMethodConfigurations.GetState().String())
-	state.ExcludedGroupIDs = excludedGroupIDs
+	state.ExcludeTargets = excludeTargets
+
+	// Include targets are only tracked when they are managed by the resource,
+	// otherwise the default 'all_users' target would always show as drift.
+	if len(state.IncludeTargets) > 0 {
+		state.IncludeTargets = sortIncludeTargets(getIncludeTargets(authenticationMethodConfigurations), state.IncludeTargets)
+	}
+
+	// Type specific settings are likewise only refreshed when managed.
+	refreshAuthMethodSettings(authenticationMethodConfigurations, state)
 
 	setStateDiags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(setStateDiags...)
@@ -166,6 +1197,12 @@ func (r *authMethodPolicyResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	lockoutDiags := r.checkLockout(ctx, &plan)
+	resp.Diagnostics.Append(lockoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deleteDiags := r.deleteAuthMethodPolicy(&state)
 	resp.Diagnostics.Append(deleteDiags...)
 	if resp.Diagnostics.HasError() {
@@ -193,6 +1230,17 @@ func (r *authMethodPolicyResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	// Destroying the resource disables the authentication method.
+	lockoutDiags := r.checkLockout(ctx, &authMethodPolicyResourceModel{
+		State:             types.StringValue("disabled"),
+		Type:              state.Type,
+		LockoutProtection: state.LockoutProtection,
+	})
+	resp.Diagnostics.Append(lockoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	deleteDiags := r.deleteAuthMethodPolicy(state)
 	resp.Diagnostics.Append(deleteDiags...)
 	if resp.Diagnostics.HasError() {
@@ -201,24 +1249,19 @@ func (r *authMethodPolicyResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *authMethodPolicyResource) createAuthMethodPolicy(plan, state *authMethodPolicyResourceModel) diag.Diagnostics {
-	excludedGroups := []graphModels.ExcludeTargetable{} //declares a non nill empty slice
-	targetType := graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE
-
-	for _, excludedGroupID := range plan.ExcludedGroupIDs {
-		excludedGroup := graphModels.NewExcludeTarget()
-		excludedGroup.SetId(StringPtr(excludedGroupID.ValueString()))
-		excludedGroup.SetTargetType(&targetType)
-		excludedGroups = append(excludedGroups, excludedGroup)
+	excludedTargets, getTargetsDiags := r.getExcludedTargets(plan)
+	if getTargetsDiags.HasError() {
+		return getTargetsDiags
 	}
 
-	requestBody := r.getAuthMethodReqBody(plan.Type.ValueString())
+	requestBody := r.getAuthMethodReqBody(plan)
 	if requestBody == nil {
 		return diag.Diagnostics{
 			diag.NewErrorDiagnostic(
 				"[API ERROR] Invalid Authentication Method Type",
-				fmt.Sprintf("'%v' for invalid, only acceptable values are 'Fido2', "+
+				fmt.Sprintf("'%v' for invalid, only acceptable values are 'Email', 'Fido2', "+
 					"'MicrosoftAuthenticator', 'Voice', 'Sms', 'SoftwareOath', "+
-					"'TemporaryAccessPass' and 'X509Certificate'.",
+					"'TemporaryAccessPass', 'X509Certificate', 'HardwareOath' and 'QRCodePin'.",
 					plan.Type.ValueString()),
 			),
 		}
@@ -228,14 +1271,10 @@ func (r *authMethodPolicyResource) createAuthMethodPolicy(plan, state *authMetho
 		return getStateDiags
 	}
 	requestBody.SetState(&authMethodPolicyState)
-	requestBody.SetExcludeTargets(excludedGroups)
+	requestBody.SetExcludeTargets(excludedTargets)
 
 	updateAuthMethodPolicy := func() error {
-		_, err := r.client.Policies().
-			AuthenticationMethodsPolicy().
-			AuthenticationMethodConfigurations().
-			ByAuthenticationMethodConfigurationId(plan.Type.ValueString()).
-			Patch(context.Background(), requestBody, nil)
+		err := r.patchAuthMethodConfiguration(plan.Type.ValueString(), requestBody)
 
 		if err != nil {
 			return handleAPIError(err)
@@ -263,26 +1302,79 @@ func (r *authMethodPolicyResource) createAuthMethodPolicy(plan, state *authMetho
 
 	*state = *plan
 
+	// Resolve the computed type specific include target attributes to the
+	// values applied by Graph API.
+	resolveIncludeTargets(state.Type.ValueString(), state.IncludeTargets)
+
 	return nil
 }
 
+// getExcludedTargets merges the declared exclude targets, the groups resolved
+// from the excluded group names and the provider-wide excluded groups.
+func (r *authMethodPolicyResource) getExcludedTargets(plan *authMethodPolicyResourceModel) ([]graphModels.ExcludeTargetable, diag.Diagnostics) {
+	excludedTargets := []graphModels.ExcludeTargetable{} //declares a non nill empty slice
+
+	for _, target := range plan.ExcludeTargets {
+		targetType := graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE
+		if parsed, _ := graphModels.ParseAuthenticationMethodTargetType(target.TargetType.ValueString()); parsed != nil {
+			targetType = *parsed.(*graphModels.AuthenticationMethodTargetType)
+		}
+
+		excludedTarget := graphModels.NewExcludeTarget()
+		excludedTarget.SetId(StringPtr(target.ID.ValueString()))
+		excludedTarget.SetTargetType(&targetType)
+		excludedTargets = append(excludedTargets, excludedTarget)
+	}
+
+	// Merge the groups resolved from the excluded group names.
+	if !plan.ExcludedGroupNameIDs.IsNull() && !plan.ExcludedGroupNameIDs.IsUnknown() {
+		var groupNameIDs map[string]string
+		mapDiags := plan.ExcludedGroupNameIDs.ElementsAs(context.Background(), &groupNameIDs, false)
+		if mapDiags.HasError() {
+			return nil, mapDiags
+		}
+
+		for _, groupID := range groupNameIDs {
+			excludedTargets = appendExcludedGroups(excludedTargets, []string{groupID})
+		}
+	}
+
+	// The groups excluded provider-wide are always merged.
+	excludedTargets = appendExcludedGroups(excludedTargets, r.alwaysExcludedGroupIDs)
+
+	return excludedTargets, nil
+}
+
 func (r *authMethodPolicyResource) deleteAuthMethodPolicy(state *authMethodPolicyResourceModel) diag.Diagnostics {
-	requestBody := r.getAuthMethodReqBody(state.Type.ValueString())
+	// Include targets managed by the resource are restored to the default of
+	// all users.
+	var includeTargets []authMethodIncludeTargetModel
+	if len(state.IncludeTargets) > 0 {
+		includeTargets = []authMethodIncludeTargetModel{
+			{
+				ID:                     types.StringValue(allUsersTargetID),
+				IsRegistrationRequired: types.BoolValue(false),
+				AuthenticationMode:     types.StringNull(),
+				IsUsableForSignIn:      types.BoolNull(),
+			},
+		}
+	}
+
+	requestBody := r.getAuthMethodReqBody(&authMethodPolicyResourceModel{
+		Type:           state.Type,
+		IncludeTargets: includeTargets,
+	})
 	authMethodPolicyState, getStateDiags := r.getState("disabled")
 	if getStateDiags != nil {
 		return getStateDiags
 	}
 
 	requestBody.SetState(&authMethodPolicyState)
-	emptyTarget := []graphModels.ExcludeTargetable{}
-	requestBody.SetExcludeTargets(emptyTarget)
+	// The groups excluded provider-wide stay excluded.
+	requestBody.SetExcludeTargets(appendExcludedGroups([]graphModels.ExcludeTargetable{}, r.alwaysExcludedGroupIDs))
 
 	deleteAuthMethodPolicy := func() error {
-		_, err := r.client.Policies().
-			AuthenticationMethodsPolicy().
-			AuthenticationMethodConfigurations().
-			ByAuthenticationMethodConfigurationId(state.Type.ValueString()).
-			Patch(context.Background(), requestBody, nil)
+		err := r.patchAuthMethodConfiguration(state.Type.ValueString(), requestBody)
 
 		if err != nil {
 			return handleAPIError(err)
@@ -309,21 +1401,374 @@ func (r *authMethodPolicyResource) deleteAuthMethodPolicy(state *authMethodPolic
 	return nil
 }
 
-func StringPtr(s string) *string {
-	return &s
+// The values reported in the registered methods of the user registration
+// details for each authentication method type. The types missing from the map,
+// such as X509Certificate, are not reported and cannot be checked.
+var authMethodRegisteredMethods = map[string][]string{
+	"Email":                  {"email"},
+	"Fido2":                  {"fido2", "passKeyDeviceBound", "passKeyDeviceBoundAuthenticator", "passKeyDeviceBoundWindowsHello"},
+	"MicrosoftAuthenticator": {"microsoftAuthenticatorPush", "microsoftAuthenticatorPasswordless"},
+	"Sms":                    {"mobilePhone"},
+	"Voice":                  {"mobilePhone", "alternateMobilePhone", "officePhone"},
+	"SoftwareOath":           {"softwareOneTimePasscode"},
+	"HardwareOath":           {"hardwareOneTimePasscode"},
+	"TemporaryAccessPass":    {"temporaryAccessPass"},
 }
 
-func (r *authMethodPolicyResource) getState(authMethodState string) (graphModels.AuthenticationMethodState, diag.Diagnostics) {
-	var state graphModels.AuthenticationMethodState
+// The registered methods that stay usable whatever the authentication methods
+// policy is.
+var alwaysUsableRegisteredMethods = []string{"windowsHelloForBusiness"}
 
-	switch authMethodState {
-	case "enabled":
-		state = graphModels.ENABLED_AUTHENTICATIONMETHODSTATE
-		return state, nil
-	case "disabled":
-		state = graphModels.DISABLED_AUTHENTICATIONMETHODSTATE
-		return state, nil
-	default:
+// The maximum number of stranded users listed in the lockout error.
+const maxListedStrandedUsers = 20
+
+// checkLockout refuses to disable the authentication method, or to exclude
+// more users from it, if that would leave more users than allowed, or any
+// member of a protected group, without a usable authentication method.
+func (r *authMethodPolicyResource) checkLockout(ctx context.Context, plan *authMethodPolicyResourceModel) diag.Diagnostics {
+	protection := plan.LockoutProtection
+	if protection == nil || protection.Force.ValueBool() {
+		return nil
+	}
+
+	authMethodType := plan.Type.ValueString()
+	registeredMethods, ok := authMethodRegisteredMethods[authMethodType]
+	if !ok {
+		return diag.Diagnostics{
+			diag.NewWarningDiagnostic(
+				"Lockout Protection Not Available",
+				fmt.Sprintf("The registrations of the '%v' authentication method are not reported by "+
+					"Microsoft Graph API, the change is applied without checking for stranded users.",
+					authMethodType),
+			),
+		}
+	}
+
+	apiErrorDiags := func(err error) diag.Diagnostics {
+		return diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"[API ERROR] Unable to Check Authentication Method Registrations",
+				"An unexpected error occurred while checking the authentication methods registered "+
+					"by the users from Microsoft Entra ID via Microsoft Graph API. Please verify that "+
+					"the API permissions are correctly configured, or set 'lockout_protection.force' "+
+					"to true to skip the check.\n\n"+
+					"Microsoft Graph API Error: "+err.Error(),
+			),
+		}
+	}
+
+	var authMethodConfiguration graphModels.AuthenticationMethodConfigurationable
+	var authMethodConfigurations graphModels.AuthenticationMethodConfigurationCollectionResponseable
+	getAuthMethodConfigurations := func() error {
+		var err error
+		authMethodConfiguration, err = r.getAuthMethodConfiguration(authMethodType)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		authMethodConfigurations, err = r.client.Policies().
+			AuthenticationMethodsPolicy().
+			AuthenticationMethodConfigurations().
+			Get(ctx, nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(getAuthMethodConfigurations, reconnectBackoff)
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	// No user can be using an authentication method that is disabled.
+	if authMethodConfiguration.GetState() == nil ||
+		*authMethodConfiguration.GetState() == graphModels.DISABLED_AUTHENTICATIONMETHODSTATE {
+		return nil
+	}
+
+	// Disabling the authentication method affects every user, excluding more
+	// targets only affects the newly excluded users.
+	var affectedUserIDs map[string]bool
+	isDisabling := plan.State.ValueString() == "disabled"
+	if !isDisabling {
+		excludedTargets, getTargetsDiags := r.getExcludedTargets(plan)
+		if getTargetsDiags.HasError() {
+			return getTargetsDiags
+		}
+
+		affectedUserIDs = map[string]bool{}
+		isExcluding := false
+		for _, target := range excludedTargets {
+			if slices.ContainsFunc(authMethodConfiguration.GetExcludeTargets(), func(currentTarget graphModels.ExcludeTargetable) bool {
+				return currentTarget.GetId() != nil && *currentTarget.GetId() == *target.GetId()
+			}) {
+				continue
+			}
+			isExcluding = true
+
+			if target.GetTargetType() != nil && *target.GetTargetType() == graphModels.USER_AUTHENTICATIONMETHODTARGETTYPE {
+				affectedUserIDs[*target.GetId()] = true
+				continue
+			}
+
+			memberIDs, err := getGroupMemberIDs(ctx, r.client, *target.GetId())
+			if err != nil {
+				return apiErrorDiags(err)
+			}
+			for memberID := range memberIDs {
+				affectedUserIDs[memberID] = true
+			}
+		}
+
+		if !isExcluding {
+			return nil
+		}
+	}
+
+	// The other enabled authentication methods stay usable for the users they
+	// target.
+	var usableMethods []usableAuthMethod
+	groupMemberIDs := map[string]map[string]bool{}
+	for _, configuration := range authMethodConfigurations.GetValue() {
+		if configuration.GetId() == nil || *configuration.GetId() == authMethodType ||
+			configuration.GetState() == nil || *configuration.GetState() != graphModels.ENABLED_AUTHENTICATIONMETHODSTATE ||
+			len(authMethodRegisteredMethods[*configuration.GetId()]) == 0 {
+			continue
+		}
+
+		usableMethod, err := r.getUsableAuthMethod(ctx, configuration, groupMemberIDs)
+		if err != nil {
+			return apiErrorDiags(err)
+		}
+		usableMethods = append(usableMethods, usableMethod)
+	}
+
+	isRegistered := func(methods, candidates []string) bool {
+		return slices.ContainsFunc(methods, func(method string) bool {
+			return slices.Contains(candidates, method)
+		})
+	}
+	hasUsableMethod := func(userID string, methods []string) bool {
+		if isRegistered(methods, alwaysUsableRegisteredMethods) {
+			return true
+		}
+		return slices.ContainsFunc(usableMethods, func(usableMethod usableAuthMethod) bool {
+			return usableMethod.isTargeted(userID) && isRegistered(methods, usableMethod.registeredMethods)
+		})
+	}
+
+	// The users left without a usable authentication method, by ID.
+	strandedUsers := map[string]string{}
+	requestBuilder := r.client.Reports().AuthenticationMethods().UserRegistrationDetails()
+	listRegistrationDetails := func(filter string) error {
+		var requestConfig *graphReports.AuthenticationMethodsUserRegistrationDetailsRequestBuilderGetRequestConfiguration
+		if filter != "" {
+			requestConfig = &graphReports.AuthenticationMethodsUserRegistrationDetailsRequestBuilderGetRequestConfiguration{
+				QueryParameters: &graphReports.AuthenticationMethodsUserRegistrationDetailsRequestBuilderGetQueryParameters{
+					Filter: &filter,
+				},
+			}
+		}
+
+		return iteratePages(func(nextLink *string) (graphModels.UserRegistrationDetailsCollectionResponseable, error) {
+			if nextLink != nil {
+				return requestBuilder.WithUrl(*nextLink).Get(ctx, nil)
+			}
+			return requestBuilder.Get(ctx, requestConfig)
+		}, func(details graphModels.UserRegistrationDetailsable) {
+			if details.GetId() == nil || (affectedUserIDs != nil && !affectedUserIDs[*details.GetId()]) {
+				return
+			}
+			if !isRegistered(details.GetMethodsRegistered(), registeredMethods) ||
+				hasUsableMethod(*details.GetId(), details.GetMethodsRegistered()) {
+				return
+			}
+
+			strandedUsers[*details.GetId()] = *details.GetId()
+			if details.GetUserPrincipalName() != nil {
+				strandedUsers[*details.GetId()] = *details.GetUserPrincipalName()
+			}
+		})
+	}
+
+	// Only the affected users are queried when some targets are newly
+	// excluded, falling back to every user when Microsoft Graph API does not
+	// support the filter.
+	if affectedUserIDs == nil {
+		err = listRegistrationDetails("")
+	} else {
+		for _, filter := range getUserIDFilters(affectedUserIDs) {
+			err = listRegistrationDetails(filter)
+			if err != nil {
+				break
+			}
+		}
+		if isBadRequestError(err) {
+			err = listRegistrationDetails("")
+		}
+	}
+	if err != nil {
+		return apiErrorDiags(err)
+	}
+
+	if len(strandedUsers) == 0 {
+		return nil
+	}
+
+	var protectedUsers []string
+	for _, groupID := range protection.ProtectedGroupIDs {
+		memberIDs, err := getGroupMemberIDs(ctx, r.client, groupID.ValueString())
+		if err != nil {
+			return apiErrorDiags(err)
+		}
+		for userID, userName := range strandedUsers {
+			if memberIDs[userID] && !slices.Contains(protectedUsers, userName) {
+				protectedUsers = append(protectedUsers, userName)
+			}
+		}
+	}
+
+	maxStrandedUsers := protection.MaxStrandedUsers.ValueInt64()
+	if int64(len(strandedUsers)) <= maxStrandedUsers && len(protectedUsers) == 0 {
+		return nil
+	}
+
+	userNames := []string{}
+	for _, userName := range strandedUsers {
+		userNames = append(userNames, userName)
+	}
+	slices.Sort(userNames)
+	slices.Sort(protectedUsers)
+	if len(userNames) > maxListedStrandedUsers {
+		userNames = append(userNames[:maxListedStrandedUsers],
+			fmt.Sprintf("... and %d more", len(userNames)-maxListedStrandedUsers))
+	}
+
+	change := "Excluding the new targets from"
+	if isDisabling {
+		change = "Disabling"
+	}
+	detail := fmt.Sprintf("%v the '%v' authentication method would leave %d user(s) without any usable "+
+		"authentication method, while at most %d are allowed by 'lockout_protection.max_stranded_users':\n\n%v",
+		change, authMethodType, len(strandedUsers), maxStrandedUsers, strings.Join(userNames, "\n"))
+	if len(protectedUsers) > 0 {
+		detail += "\n\nThe following users are members of the protected groups:\n\n" + strings.Join(protectedUsers, "\n")
+	}
+
+	return diag.Diagnostics{
+		diag.NewErrorDiagnostic(
+			"[INPUT ERROR] Authentication Method Lockout Risk",
+			detail+"\n\nRegister another authentication method for these users first, or set "+
+				"'lockout_protection.force' to true to apply the change anyway.",
+		),
+	}
+}
+
+// The maximum number of user IDs in each filter of the user registration
+// details.
+const maxFilteredUserIDs = 15
+
+// getUserIDFilters builds the OData filters matching the given user IDs, in
+// batches of maxFilteredUserIDs.
+func getUserIDFilters(userIDs map[string]bool) []string {
+	ids := slices.Sorted(maps.Keys(userIDs))
+
+	filters := []string{}
+	for batch := range slices.Chunk(ids, maxFilteredUserIDs) {
+		quotedIDs := []string{}
+		for _, id := range batch {
+			quotedIDs = append(quotedIDs, "'"+strings.ReplaceAll(id, "'", "''")+"'")
+		}
+		filters = append(filters, fmt.Sprintf("id in (%v)", strings.Join(quotedIDs, ",")))
+	}
+	return filters
+}
+
+// usableAuthMethod is another enabled authentication method, with the users it
+// targets resolved.
+type usableAuthMethod struct {
+	registeredMethods []string
+	includesAllUsers  bool
+	includedUserIDs   map[string]bool
+	excludedUserIDs   map[string]bool
+}
+
+func (m usableAuthMethod) isTargeted(userID string) bool {
+	return (m.includesAllUsers || m.includedUserIDs[userID]) && !m.excludedUserIDs[userID]
+}
+
+// getUsableAuthMethod resolves the users targeted by an authentication method
+// configuration. The members of each group are cached in groupMemberIDs. As
+// the include targets are read without their type, an include target that is
+// not a group is taken as a user.
+func (r *authMethodPolicyResource) getUsableAuthMethod(ctx context.Context, config graphModels.AuthenticationMethodConfigurationable,
+	groupMemberIDs map[string]map[string]bool) (usableAuthMethod, error) {
+	usableMethod := usableAuthMethod{
+		registeredMethods: authMethodRegisteredMethods[*config.GetId()],
+		includedUserIDs:   map[string]bool{},
+		excludedUserIDs:   map[string]bool{},
+	}
+
+	getMemberIDs := func(groupID string) (map[string]bool, error) {
+		if memberIDs, ok := groupMemberIDs[groupID]; ok {
+			return memberIDs, nil
+		}
+		memberIDs, err := getGroupMemberIDs(ctx, r.client, groupID)
+		if isNotFoundError(err) {
+			memberIDs, err = map[string]bool{groupID: true}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		groupMemberIDs[groupID] = memberIDs
+		return memberIDs, nil
+	}
+
+	for _, target := range getIncludeTargets(config) {
+		if target.ID.ValueString() == allUsersTargetID {
+			usableMethod.includesAllUsers = true
+			continue
+		}
+		memberIDs, err := getMemberIDs(target.ID.ValueString())
+		if err != nil {
+			return usableMethod, err
+		}
+		maps.Copy(usableMethod.includedUserIDs, memberIDs)
+	}
+
+	for _, target := range getExcludeTargets(config) {
+		if target.TargetType.ValueString() == graphModels.USER_AUTHENTICATIONMETHODTARGETTYPE.String() {
+			usableMethod.excludedUserIDs[target.ID.ValueString()] = true
+			continue
+		}
+		memberIDs, err := getMemberIDs(target.ID.ValueString())
+		if err != nil {
+			return usableMethod, err
+		}
+		maps.Copy(usableMethod.excludedUserIDs, memberIDs)
+	}
+
+	return usableMethod, nil
+}
+
+func StringPtr(s string) *string {
+	return &s
+}
+
+func (r *authMethodPolicyResource) getState(authMethodState string) (graphModels.AuthenticationMethodState, diag.Diagnostics) {
+	var state graphModels.AuthenticationMethodState
+
+	switch authMethodState {
+	case "enabled":
+		state = graphModels.ENABLED_AUTHENTICATIONMETHODSTATE
+		return state, nil
+	case "disabled":
+		state = graphModels.DISABLED_AUTHENTICATIONMETHODSTATE
+		return state, nil
+	default:
 		return state, diag.Diagnostics{
 			diag.NewErrorDiagnostic(
 				"[API ERROR] Invalid Authentication Method State",
@@ -334,29 +1779,1041 @@ func (r *authMethodPolicyResource) getState(authMethodState string) (graphModels
 	}
 }
 
-func (r *authMethodPolicyResource) getAuthMethodReqBody(authMethodType string) graphModels.AuthenticationMethodConfigurationable {
+func (r *authMethodPolicyResource) getAuthMethodReqBody(plan *authMethodPolicyResourceModel) graphModels.AuthenticationMethodConfigurationable {
 	var requestBody graphModels.AuthenticationMethodConfigurationable
 
-	switch authMethodType {
+	// Include targets are only sent when configured, so that an omitted
+	// include_target block leaves the existing targets untouched.
+	var includeTargets []graphModels.AuthenticationMethodTargetable
+	if len(plan.IncludeTargets) > 0 {
+		includeTargets = getAuthMethodTargets(plan.IncludeTargets)
+	}
+
+	switch plan.Type.ValueString() {
 	case "Email":
-		requestBody = graphModels.NewEmailAuthenticationMethodConfiguration()
+		config := graphModels.NewEmailAuthenticationMethodConfiguration()
+		config.SetIncludeTargets(includeTargets)
+		if plan.EmailSettings != nil {
+			if state, _ := graphModels.ParseExternalEmailOtpState(plan.EmailSettings.AllowExternalIDToUseEmailOtp.ValueString()); state != nil {
+				config.SetAllowExternalIdToUseEmailOtp(state.(*graphModels.ExternalEmailOtpState))
+			}
+		}
+		requestBody = config
 	case "Fido2":
-		requestBody = graphModels.NewFido2AuthenticationMethodConfiguration()
+		config := graphModels.NewFido2AuthenticationMethodConfiguration()
+		config.SetIncludeTargets(includeTargets)
+		if plan.Fido2Settings != nil {
+			setFido2Settings(config, plan.Fido2Settings)
+		}
+		requestBody = config
 	case "MicrosoftAuthenticator":
-		requestBody = graphModels.NewMicrosoftAuthenticatorAuthenticationMethodConfiguration()
+		config := graphModels.NewMicrosoftAuthenticatorAuthenticationMethodConfiguration()
+		if len(plan.IncludeTargets) > 0 {
+			config.SetIncludeTargets(getMicrosoftAuthenticatorTargets(plan.IncludeTargets))
+		}
+		if plan.MicrosoftAuthenticatorSettings != nil {
+			setMicrosoftAuthenticatorSettings(config, plan.MicrosoftAuthenticatorSettings)
+		}
+		requestBody = config
 	case "Voice":
-		requestBody = graphModels.NewVoiceAuthenticationMethodConfiguration()
+		config := graphModels.NewVoiceAuthenticationMethodConfiguration()
+		config.SetIncludeTargets(includeTargets)
+		if plan.VoiceSettings != nil {
+			config.SetIsOfficePhoneAllowed(plan.VoiceSettings.IsOfficePhoneAllowed.ValueBoolPointer())
+		}
+		requestBody = config
 	case "Sms":
-		requestBody = graphModels.NewSmsAuthenticationMethodConfiguration()
+		config := graphModels.NewSmsAuthenticationMethodConfiguration()
+		if len(plan.IncludeTargets) > 0 {
+			config.SetIncludeTargets(getSmsTargets(plan.IncludeTargets))
+		}
+		requestBody = config
 	case "SoftwareOath":
-		requestBody = graphModels.NewSoftwareOathAuthenticationMethodConfiguration()
+		config := graphModels.NewSoftwareOathAuthenticationMethodConfiguration()
+		config.SetIncludeTargets(includeTargets)
+		requestBody = config
 	case "TemporaryAccessPass":
-		requestBody = graphModels.NewTemporaryAccessPassAuthenticationMethodConfiguration()
+		config := graphModels.NewTemporaryAccessPassAuthenticationMethodConfiguration()
+		config.SetIncludeTargets(includeTargets)
+		if plan.TemporaryAccessPassSettings != nil {
+			setTemporaryAccessPassSettings(config, plan.TemporaryAccessPassSettings)
+		}
+		requestBody = config
 	case "X509Certificate":
-		requestBody = graphModels.NewX509CertificateAuthenticationMethodConfiguration()
+		config := graphModels.NewX509CertificateAuthenticationMethodConfiguration()
+		config.SetIncludeTargets(includeTargets)
+		if plan.X509CertificateSettings != nil {
+			setX509CertificateSettings(config, plan.X509CertificateSettings)
+		}
+		requestBody = config
+	case "HardwareOath", "QRCodePin":
+		// Not available in the v1.0 SDK, the type specific properties are sent
+		// as additional data to the beta endpoint.
+		config := graphModels.NewAuthenticationMethodConfiguration()
+		config.SetOdataType(StringPtr(betaAuthMethodOdataTypes[plan.Type.ValueString()]))
+		additionalData := config.GetAdditionalData()
+		if len(plan.IncludeTargets) > 0 {
+			additionalData["includeTargets"] = getAuthMethodTargetsData(plan.IncludeTargets)
+		}
+		if plan.QRCodePinSettings != nil {
+			additionalData["pinLength"] = plan.QRCodePinSettings.PinLength.ValueInt64()
+			additionalData["standardQRCodeLifetimeInDays"] = plan.QRCodePinSettings.StandardQRCodeLifetimeInDays.ValueInt64()
+		}
+		config.SetAdditionalData(additionalData)
+		requestBody = config
 	default:
 		requestBody = nil
 	}
 
 	return requestBody
 }
+
+// The authentication method types supported by the provider.
+var authMethodTypes = []string{
+	"Email", "Fido2", "MicrosoftAuthenticator", "Voice", "Sms", "SoftwareOath",
+	"TemporaryAccessPass", "X509Certificate", "HardwareOath", "QRCodePin",
+}
+
+// The authentication method types only available in the Microsoft Graph API
+// beta endpoint, with the OData type of their configuration.
+var betaAuthMethodOdataTypes = map[string]string{
+	"HardwareOath": "#microsoft.graph.hardwareOathAuthenticationMethodConfiguration",
+	"QRCodePin":    "#microsoft.graph.qrCodePinAuthenticationMethodConfiguration",
+}
+
+// The authentication method types read and updated through the beta endpoint,
+// as some of their settings are only part of the beta schema. Besides the
+// types missing from the v1.0 SDK, the number matching and companion app
+// features of Microsoft Authenticator are only returned by the beta endpoint.
+func isBetaAuthMethodType(authMethodType string) bool {
+	_, ok := betaAuthMethodOdataTypes[authMethodType]
+	return ok || authMethodType == "MicrosoftAuthenticator"
+}
+
+func authMethodConfigurationPath(authMethodType string) string {
+	return "/policies/authenticationMethodsPolicy/authenticationMethodConfigurations/" + url.PathEscape(authMethodType)
+}
+
+// getAuthMethodConfiguration gets an authentication method configuration,
+// from the beta endpoint if the type has settings only in the beta schema. The
+// settings missing from the v1.0 SDK are kept as additional data.
+func (r *authMethodPolicyResource) getAuthMethodConfiguration(authMethodType string) (graphModels.AuthenticationMethodConfigurationable, error) {
+	if isBetaAuthMethodType(authMethodType) {
+		result, err := sendBetaRequest(r.client, abstractions.GET, authMethodConfigurationPath(authMethodType),
+			nil, graphModels.CreateAuthenticationMethodConfigurationFromDiscriminatorValue)
+		if err != nil {
+			return nil, err
+		}
+		return result.(graphModels.AuthenticationMethodConfigurationable), nil
+	}
+
+	return r.client.Policies().
+		AuthenticationMethodsPolicy().
+		AuthenticationMethodConfigurations().
+		ByAuthenticationMethodConfigurationId(authMethodType).
+		Get(context.Background(), nil)
+}
+
+// patchAuthMethodConfiguration updates an authentication method configuration,
+// through the beta endpoint if the type has settings only in the beta schema.
+func (r *authMethodPolicyResource) patchAuthMethodConfiguration(authMethodType string, requestBody graphModels.AuthenticationMethodConfigurationable) error {
+	if isBetaAuthMethodType(authMethodType) {
+		_, err := sendBetaRequest(r.client, abstractions.PATCH, authMethodConfigurationPath(authMethodType),
+			requestBody, nil)
+		return err
+	}
+
+	_, err := r.client.Policies().
+		AuthenticationMethodsPolicy().
+		AuthenticationMethodConfigurations().
+		ByAuthenticationMethodConfigurationId(authMethodType).
+		Patch(context.Background(), requestBody, nil)
+	return err
+}
+
+// The target ID used by Microsoft Entra ID to target every user in the tenant.
+const allUsersTargetID = "all_users"
+
+// The target ID used by Microsoft Entra ID to target no one.
+const noneTargetID = "00000000-0000-0000-0000-000000000000"
+
+// The ranges of the Temporary Access Pass settings accepted by Graph API.
+const (
+	tapMinLifetimeInMinutes = 10
+	tapMaxLifetimeInMinutes = 43200
+	tapMinLength            = 8
+	tapMaxLength            = 48
+
+	tapDefaultLifetimeInMinutes        = 60
+	tapDefaultMinimumLifetimeInMinutes = 60
+	tapDefaultMaximumLifetimeInMinutes = 480
+	tapDefaultLength                   = 8
+)
+
+// The ranges of the QR code settings accepted by Graph API.
+const (
+	qrCodeMinPinLength      = 8
+	qrCodeMaxPinLength      = 20
+	qrCodeMinLifetimeInDays = 1
+	qrCodeMaxLifetimeInDays = 395
+
+	qrCodeDefaultPinLength      = 8
+	qrCodeDefaultLifetimeInDays = 365
+)
+
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func getAuthMethodTarget(target authMethodIncludeTargetModel) *graphModels.AuthenticationMethodTarget {
+	targetType := graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE
+
+	includeTarget := graphModels.NewAuthenticationMethodTarget()
+	includeTarget.SetId(StringPtr(target.ID.ValueString()))
+	includeTarget.SetTargetType(&targetType)
+	includeTarget.SetIsRegistrationRequired(target.IsRegistrationRequired.ValueBoolPointer())
+
+	return includeTarget
+}
+
+func getAuthMethodTargets(targets []authMethodIncludeTargetModel) []graphModels.AuthenticationMethodTargetable {
+	includeTargets := []graphModels.AuthenticationMethodTargetable{}
+
+	for _, target := range targets {
+		includeTargets = append(includeTargets, getAuthMethodTarget(target))
+	}
+
+	return includeTargets
+}
+
+func getAuthMethodTargetsData(targets []authMethodIncludeTargetModel) []map[string]any {
+	includeTargets := []map[string]any{}
+
+	for _, target := range targets {
+		includeTargets = append(includeTargets, map[string]any{
+			"id":                     target.ID.ValueString(),
+			"targetType":             graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE.String(),
+			"isRegistrationRequired": target.IsRegistrationRequired.ValueBool(),
+		})
+	}
+
+	return includeTargets
+}
+
+func getMicrosoftAuthenticatorTargets(targets []authMethodIncludeTargetModel) []graphModels.MicrosoftAuthenticatorAuthenticationMethodTargetable {
+	includeTargets := []graphModels.MicrosoftAuthenticatorAuthenticationMethodTargetable{}
+	targetType := graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE
+
+	for _, target := range targets {
+		includeTarget := graphModels.NewMicrosoftAuthenticatorAuthenticationMethodTarget()
+		includeTarget.SetId(StringPtr(target.ID.ValueString()))
+		includeTarget.SetTargetType(&targetType)
+		includeTarget.SetIsRegistrationRequired(target.IsRegistrationRequired.ValueBoolPointer())
+
+		authMode := graphModels.ANY_MICROSOFTAUTHENTICATORAUTHENTICATIONMODE
+		if !target.AuthenticationMode.IsNull() && !target.AuthenticationMode.IsUnknown() {
+			if mode, _ := graphModels.ParseMicrosoftAuthenticatorAuthenticationMode(target.AuthenticationMode.ValueString()); mode != nil {
+				authMode = *mode.(*graphModels.MicrosoftAuthenticatorAuthenticationMode)
+			}
+		}
+		includeTarget.SetAuthenticationMode(&authMode)
+
+		includeTargets = append(includeTargets, includeTarget)
+	}
+
+	return includeTargets
+}
+
+func getSmsTargets(targets []authMethodIncludeTargetModel) []graphModels.SmsAuthenticationMethodTargetable {
+	includeTargets := []graphModels.SmsAuthenticationMethodTargetable{}
+	targetType := graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE
+
+	for _, target := range targets {
+		includeTarget := graphModels.NewSmsAuthenticationMethodTarget()
+		includeTarget.SetId(StringPtr(target.ID.ValueString()))
+		includeTarget.SetTargetType(&targetType)
+		includeTarget.SetIsRegistrationRequired(target.IsRegistrationRequired.ValueBoolPointer())
+
+		isUsableForSignIn := true
+		if !target.IsUsableForSignIn.IsNull() && !target.IsUsableForSignIn.IsUnknown() {
+			isUsableForSignIn = target.IsUsableForSignIn.ValueBool()
+		}
+		includeTarget.SetIsUsableForSignIn(&isUsableForSignIn)
+
+		includeTargets = append(includeTargets, includeTarget)
+	}
+
+	return includeTargets
+}
+
+// refreshAuthMethodSettings refreshes the type specific settings blocks that
+// are set in the model from the authentication method configuration.
+func refreshAuthMethodSettings(config graphModels.AuthenticationMethodConfigurationable, state *authMethodPolicyResourceModel) {
+	if fido2Config, ok := config.(graphModels.Fido2AuthenticationMethodConfigurationable); ok && state.Fido2Settings != nil {
+		state.Fido2Settings = getFido2Settings(fido2Config, state.Fido2Settings)
+	}
+	if authenticatorConfig, ok := config.(graphModels.MicrosoftAuthenticatorAuthenticationMethodConfigurationable); ok && state.MicrosoftAuthenticatorSettings != nil {
+		state.MicrosoftAuthenticatorSettings = getMicrosoftAuthenticatorSettings(authenticatorConfig, state.MicrosoftAuthenticatorSettings)
+	}
+	if tapConfig, ok := config.(graphModels.TemporaryAccessPassAuthenticationMethodConfigurationable); ok && state.TemporaryAccessPassSettings != nil {
+		state.TemporaryAccessPassSettings = getTemporaryAccessPassSettings(tapConfig)
+	}
+	if x509Config, ok := config.(graphModels.X509CertificateAuthenticationMethodConfigurationable); ok && state.X509CertificateSettings != nil {
+		state.X509CertificateSettings = getX509CertificateSettings(x509Config, state.X509CertificateSettings)
+	}
+	if emailConfig, ok := config.(graphModels.EmailAuthenticationMethodConfigurationable); ok && state.EmailSettings != nil {
+		state.EmailSettings = &emailSettingsModel{
+			AllowExternalIDToUseEmailOtp: types.StringNull(),
+		}
+		if emailConfig.GetAllowExternalIdToUseEmailOtp() != nil {
+			state.EmailSettings.AllowExternalIDToUseEmailOtp = types.StringValue(emailConfig.GetAllowExternalIdToUseEmailOtp().String())
+		}
+	}
+	if voiceConfig, ok := config.(graphModels.VoiceAuthenticationMethodConfigurationable); ok && state.VoiceSettings != nil {
+		state.VoiceSettings = &voiceSettingsModel{
+			IsOfficePhoneAllowed: types.BoolPointerValue(voiceConfig.GetIsOfficePhoneAllowed()),
+		}
+	}
+	if state.Type.ValueString() == "QRCodePin" && state.QRCodePinSettings != nil {
+		state.QRCodePinSettings = getQRCodePinSettings(config)
+	}
+}
+
+// resolveIncludeTargets resolves the unknown computed type specific include
+// target attributes to the values applied by Graph API.
+func resolveIncludeTargets(authMethodType string, targets []authMethodIncludeTargetModel) {
+	for i := range targets {
+		if targets[i].AuthenticationMode.IsUnknown() {
+			if authMethodType == "MicrosoftAuthenticator" {
+				targets[i].AuthenticationMode = types.StringValue(
+					graphModels.ANY_MICROSOFTAUTHENTICATORAUTHENTICATIONMODE.String())
+			} else {
+				targets[i].AuthenticationMode = types.StringNull()
+			}
+		}
+		if targets[i].IsUsableForSignIn.IsUnknown() {
+			if authMethodType == "Sms" {
+				targets[i].IsUsableForSignIn = types.BoolValue(true)
+			} else {
+				targets[i].IsUsableForSignIn = types.BoolNull()
+			}
+		}
+	}
+}
+
+// getExcludeTargets converts the exclude targets of any authentication method
+// configuration into the resource model.
+func getExcludeTargets(config graphModels.AuthenticationMethodConfigurationable) []authMethodExcludeTargetModel {
+	var excludeTargets []authMethodExcludeTargetModel
+
+	for _, target := range config.GetExcludeTargets() {
+		if target.GetId() != nil {
+			excludeTarget := authMethodExcludeTargetModel{
+				ID:         types.StringValue(*target.GetId()),
+				TargetType: types.StringNull(),
+			}
+			if target.GetTargetType() != nil {
+				excludeTarget.TargetType = types.StringValue(target.GetTargetType().String())
+			}
+			excludeTargets = append(excludeTargets, excludeTarget)
+		}
+	}
+
+	return excludeTargets
+}
+
+// getIncludeTargets converts the include targets of any authentication method
+// configuration into the resource model.
+func getIncludeTargets(config graphModels.AuthenticationMethodConfigurationable) []authMethodIncludeTargetModel {
+	includeTargets := []authMethodIncludeTargetModel{}
+
+	newIncludeTarget := func(target graphModels.AuthenticationMethodTargetable) authMethodIncludeTargetModel {
+		isRegistrationRequired := false
+		if target.GetIsRegistrationRequired() != nil {
+			isRegistrationRequired = *target.GetIsRegistrationRequired()
+		}
+
+		return authMethodIncludeTargetModel{
+			ID:                     types.StringPointerValue(target.GetId()),
+			IsRegistrationRequired: types.BoolValue(isRegistrationRequired),
+			AuthenticationMode:     types.StringNull(),
+			IsUsableForSignIn:      types.BoolNull(),
+		}
+	}
+
+	switch config := config.(type) {
+	case graphModels.MicrosoftAuthenticatorAuthenticationMethodConfigurationable:
+		for _, target := range config.GetIncludeTargets() {
+			includeTarget := newIncludeTarget(target)
+			if target.GetAuthenticationMode() != nil {
+				includeTarget.AuthenticationMode = types.StringValue(target.GetAuthenticationMode().String())
+			}
+			includeTargets = append(includeTargets, includeTarget)
+		}
+	case graphModels.SmsAuthenticationMethodConfigurationable:
+		for _, target := range config.GetIncludeTargets() {
+			includeTarget := newIncludeTarget(target)
+			includeTarget.IsUsableForSignIn = types.BoolPointerValue(target.GetIsUsableForSignIn())
+			includeTargets = append(includeTargets, includeTarget)
+		}
+	case interface {
+		GetIncludeTargets() []graphModels.AuthenticationMethodTargetable
+	}:
+		for _, target := range config.GetIncludeTargets() {
+			includeTargets = append(includeTargets, newIncludeTarget(target))
+		}
+	default:
+		// The configurations read from the beta endpoint hold their include
+		// targets as additional data.
+		targets, _ := config.GetAdditionalData()["includeTargets"].([]any)
+		for _, target := range targets {
+			targetData, ok := target.(map[string]any)
+			if !ok {
+				continue
+			}
+			id, ok := getRawString(targetData["id"])
+			if !ok {
+				continue
+			}
+			isRegistrationRequired, _ := getRawBool(targetData["isRegistrationRequired"])
+
+			includeTargets = append(includeTargets, authMethodIncludeTargetModel{
+				ID:                     types.StringValue(id),
+				IsRegistrationRequired: types.BoolValue(isRegistrationRequired),
+				AuthenticationMode:     types.StringNull(),
+				IsUsableForSignIn:      types.BoolNull(),
+			})
+		}
+	}
+
+	return includeTargets
+}
+
+// sortIncludeTargets orders the include targets read from Graph API following
+// the order in the prior state, so that a different ordering returned by the
+// API is not reported as drift. Unknown targets are appended at the end.
+func sortIncludeTargets(targets, priorTargets []authMethodIncludeTargetModel) []authMethodIncludeTargetModel {
+	sorted := []authMethodIncludeTargetModel{}
+	used := make([]bool, len(targets))
+
+	for _, priorTarget := range priorTargets {
+		for i, target := range targets {
+			if !used[i] && target.ID.Equal(priorTarget.ID) {
+				sorted = append(sorted, target)
+				used[i] = true
+				break
+			}
+		}
+	}
+
+	for i, target := range targets {
+		if !used[i] {
+			sorted = append(sorted, target)
+		}
+	}
+
+	return sorted
+}
+
+func setFido2Settings(config graphModels.Fido2AuthenticationMethodConfigurationable, settings *fido2SettingsModel) {
+	config.SetIsSelfServiceRegistrationAllowed(settings.IsSelfServiceRegistrationAllowed.ValueBoolPointer())
+	config.SetIsAttestationEnforced(settings.IsAttestationEnforced.ValueBoolPointer())
+
+	if settings.KeyRestrictions == nil {
+		return
+	}
+
+	aaGuids := []string{}
+	for _, aaGuid := range settings.KeyRestrictions.AaGuids {
+		aaGuids = append(aaGuids, aaGuid.ValueString())
+	}
+
+	enforcementType := graphModels.BLOCK_FIDO2RESTRICTIONENFORCEMENTTYPE
+	if settings.KeyRestrictions.EnforcementType.ValueString() == "allow" {
+		enforcementType = graphModels.ALLOW_FIDO2RESTRICTIONENFORCEMENTTYPE
+	}
+
+	keyRestrictions := graphModels.NewFido2KeyRestrictions()
+	keyRestrictions.SetIsEnforced(settings.KeyRestrictions.IsEnforced.ValueBoolPointer())
+	keyRestrictions.SetEnforcementType(&enforcementType)
+	keyRestrictions.SetAaGuids(aaGuids)
+	config.SetKeyRestrictions(keyRestrictions)
+}
+
+func getFido2Settings(config graphModels.Fido2AuthenticationMethodConfigurationable, priorSettings *fido2SettingsModel) *fido2SettingsModel {
+	settings := &fido2SettingsModel{
+		IsSelfServiceRegistrationAllowed: types.BoolPointerValue(config.GetIsSelfServiceRegistrationAllowed()),
+		IsAttestationEnforced:            types.BoolPointerValue(config.GetIsAttestationEnforced()),
+	}
+
+	keyRestrictions := config.GetKeyRestrictions()
+	if priorSettings.KeyRestrictions == nil || keyRestrictions == nil {
+		return settings
+	}
+
+	settings.KeyRestrictions = &fido2KeyRestrictionsModel{
+		IsEnforced:      types.BoolPointerValue(keyRestrictions.GetIsEnforced()),
+		EnforcementType: types.StringNull(),
+	}
+	if keyRestrictions.GetEnforcementType() != nil {
+		settings.KeyRestrictions.EnforcementType = types.StringValue(keyRestrictions.GetEnforcementType().String())
+	}
+
+	// AAGUIDs are case insensitive, keep the casing used in the prior state.
+	for _, aaGuid := range keyRestrictions.GetAaGuids() {
+		value := types.StringValue(aaGuid)
+		for _, priorAaGuid := range priorSettings.KeyRestrictions.AaGuids {
+			if strings.EqualFold(priorAaGuid.ValueString(), aaGuid) {
+				value = priorAaGuid
+				break
+			}
+		}
+		settings.KeyRestrictions.AaGuids = append(settings.KeyRestrictions.AaGuids, value)
+	}
+
+	return settings
+}
+
+// The Microsoft Authenticator feature settings that are only part of the
+// Microsoft Graph API beta schema, sent to and read from the beta endpoint as
+// additional data.
+const (
+	numberMatchingFeatureKey = "numberMatchingRequiredState"
+	companionAppFeatureKey   = "companionAppAllowedState"
+)
+
+func setMicrosoftAuthenticatorSettings(config graphModels.MicrosoftAuthenticatorAuthenticationMethodConfigurationable, settings *microsoftAuthenticatorSettingsModel) {
+	config.SetIsSoftwareOathEnabled(settings.IsSoftwareOathEnabled.ValueBoolPointer())
+
+	featureSettings := graphModels.NewMicrosoftAuthenticatorFeatureSettings()
+	if settings.DisplayAppInformation != nil {
+		featureSettings.SetDisplayAppInformationRequiredState(getAuthenticatorFeature(settings.DisplayAppInformation))
+	}
+	if settings.DisplayLocationInformation != nil {
+		featureSettings.SetDisplayLocationInformationRequiredState(getAuthenticatorFeature(settings.DisplayLocationInformation))
+	}
+
+	additionalData := featureSettings.GetAdditionalData()
+	if settings.NumberMatching != nil {
+		additionalData[numberMatchingFeatureKey] = getAuthenticatorFeatureData(settings.NumberMatching)
+	}
+	if settings.CompanionApp != nil {
+		additionalData[companionAppFeatureKey] = getAuthenticatorFeatureData(settings.CompanionApp)
+	}
+	featureSettings.SetAdditionalData(additionalData)
+
+	config.SetFeatureSettings(featureSettings)
+}
+
+func getAuthenticatorFeature(feature *authenticatorFeatureModel) graphModels.AuthenticationMethodFeatureConfigurationable {
+	featureConfig := graphModels.NewAuthenticationMethodFeatureConfiguration()
+
+	if state, _ := graphModels.ParseAdvancedConfigState(feature.State.ValueString()); state != nil {
+		featureConfig.SetState(state.(*graphModels.AdvancedConfigState))
+	}
+
+	newFeatureTarget := func(id, targetType types.String) graphModels.FeatureTargetable {
+		featureTarget := graphModels.NewFeatureTarget()
+		featureTarget.SetId(StringPtr(id.ValueString()))
+		if parsed, _ := graphModels.ParseFeatureTargetType(targetType.ValueString()); parsed != nil {
+			featureTarget.SetTargetType(parsed.(*graphModels.FeatureTargetType))
+		}
+		return featureTarget
+	}
+
+	featureConfig.SetIncludeTarget(newFeatureTarget(feature.IncludeTargetID, feature.IncludeTargetType))
+	featureConfig.SetExcludeTarget(newFeatureTarget(feature.ExcludeTargetID, feature.ExcludeTargetType))
+
+	return featureConfig
+}
+
+func getAuthenticatorFeatureData(feature *authenticatorFeatureModel) map[string]any {
+	return map[string]any{
+		"state": feature.State.ValueString(),
+		"includeTarget": map[string]any{
+			"id":         feature.IncludeTargetID.ValueString(),
+			"targetType": feature.IncludeTargetType.ValueString(),
+		},
+		"excludeTarget": map[string]any{
+			"id":         feature.ExcludeTargetID.ValueString(),
+			"targetType": feature.ExcludeTargetType.ValueString(),
+		},
+	}
+}
+
+func getMicrosoftAuthenticatorSettings(config graphModels.MicrosoftAuthenticatorAuthenticationMethodConfigurationable, priorSettings *microsoftAuthenticatorSettingsModel) *microsoftAuthenticatorSettingsModel {
+	settings := &microsoftAuthenticatorSettingsModel{
+		IsSoftwareOathEnabled: types.BoolPointerValue(config.GetIsSoftwareOathEnabled()),
+	}
+
+	featureSettings := config.GetFeatureSettings()
+	if featureSettings == nil {
+		return settings
+	}
+
+	if priorSettings.DisplayAppInformation != nil {
+		settings.DisplayAppInformation = getAuthenticatorFeatureModel(featureSettings.GetDisplayAppInformationRequiredState())
+	}
+	if priorSettings.DisplayLocationInformation != nil {
+		settings.DisplayLocationInformation = getAuthenticatorFeatureModel(featureSettings.GetDisplayLocationInformationRequiredState())
+	}
+	if priorSettings.NumberMatching != nil {
+		settings.NumberMatching = getAuthenticatorFeatureModelFromData(featureSettings.GetAdditionalData()[numberMatchingFeatureKey])
+	}
+	if priorSettings.CompanionApp != nil {
+		settings.CompanionApp = getAuthenticatorFeatureModelFromData(featureSettings.GetAdditionalData()[companionAppFeatureKey])
+	}
+
+	return settings
+}
+
+func getAuthenticatorFeatureModel(featureConfig graphModels.AuthenticationMethodFeatureConfigurationable) *authenticatorFeatureModel {
+	if featureConfig == nil {
+		return nil
+	}
+
+	feature := &authenticatorFeatureModel{
+		State:             types.StringNull(),
+		IncludeTargetID:   types.StringNull(),
+		IncludeTargetType: types.StringNull(),
+		ExcludeTargetID:   types.StringNull(),
+		ExcludeTargetType: types.StringNull(),
+	}
+
+	if featureConfig.GetState() != nil {
+		feature.State = types.StringValue(featureConfig.GetState().String())
+	}
+	if includeTarget := featureConfig.GetIncludeTarget(); includeTarget != nil {
+		feature.IncludeTargetID = types.StringPointerValue(includeTarget.GetId())
+		if includeTarget.GetTargetType() != nil {
+			feature.IncludeTargetType = types.StringValue(includeTarget.GetTargetType().String())
+		}
+	}
+	if excludeTarget := featureConfig.GetExcludeTarget(); excludeTarget != nil {
+		feature.ExcludeTargetID = types.StringPointerValue(excludeTarget.GetId())
+		if excludeTarget.GetTargetType() != nil {
+			feature.ExcludeTargetType = types.StringValue(excludeTarget.GetTargetType().String())
+		}
+	}
+
+	return feature
+}
+
+// getAuthenticatorFeatureModelFromData converts a feature setting deserialized
+// as additional data, which holds raw JSON values, into the resource model.
+func getAuthenticatorFeatureModelFromData(data any) *authenticatorFeatureModel {
+	featureData, ok := data.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	getString := func(values map[string]any, key string) types.String {
+		if value, ok := getRawString(values[key]); ok {
+			return types.StringValue(value)
+		}
+		return types.StringNull()
+	}
+
+	feature := &authenticatorFeatureModel{
+		State:             getString(featureData, "state"),
+		IncludeTargetID:   types.StringNull(),
+		IncludeTargetType: types.StringNull(),
+		ExcludeTargetID:   types.StringNull(),
+		ExcludeTargetType: types.StringNull(),
+	}
+
+	if includeTarget, ok := featureData["includeTarget"].(map[string]any); ok {
+		feature.IncludeTargetID = getString(includeTarget, "id")
+		feature.IncludeTargetType = getString(includeTarget, "targetType")
+	}
+	if excludeTarget, ok := featureData["excludeTarget"].(map[string]any); ok {
+		feature.ExcludeTargetID = getString(excludeTarget, "id")
+		feature.ExcludeTargetType = getString(excludeTarget, "targetType")
+	}
+
+	return feature
+}
+
+func setTemporaryAccessPassSettings(config graphModels.TemporaryAccessPassAuthenticationMethodConfigurationable, settings *temporaryAccessPassSettingsModel) {
+	int32Ptr := func(value types.Int64) *int32 {
+		if value.IsNull() || value.IsUnknown() {
+			return nil
+		}
+		v := int32(value.ValueInt64())
+		return &v
+	}
+
+	config.SetDefaultLifetimeInMinutes(int32Ptr(settings.DefaultLifetimeInMinutes))
+	config.SetMinimumLifetimeInMinutes(int32Ptr(settings.MinimumLifetimeInMinutes))
+	config.SetMaximumLifetimeInMinutes(int32Ptr(settings.MaximumLifetimeInMinutes))
+	config.SetDefaultLength(int32Ptr(settings.DefaultLength))
+	config.SetIsUsableOnce(settings.IsUsableOnce.ValueBoolPointer())
+}
+
+func getTemporaryAccessPassSettings(config graphModels.TemporaryAccessPassAuthenticationMethodConfigurationable) *temporaryAccessPassSettingsModel {
+	int64Value := func(value *int32) types.Int64 {
+		if value == nil {
+			return types.Int64Null()
+		}
+		return types.Int64Value(int64(*value))
+	}
+
+	return &temporaryAccessPassSettingsModel{
+		DefaultLifetimeInMinutes: int64Value(config.GetDefaultLifetimeInMinutes()),
+		MinimumLifetimeInMinutes: int64Value(config.GetMinimumLifetimeInMinutes()),
+		MaximumLifetimeInMinutes: int64Value(config.GetMaximumLifetimeInMinutes()),
+		DefaultLength:            int64Value(config.GetDefaultLength()),
+		IsUsableOnce:             types.BoolPointerValue(config.GetIsUsableOnce()),
+	}
+}
+
+var (
+	x509CertificateFields = []string{
+		"PrincipalName", "RFC822Name", "SubjectKeyIdentifier", "SHA1PublicKey",
+		"IssuerAndSubject", "Subject", "IssuerAndSerialNumber",
+	}
+	x509CertificateUserProperties = []string{
+		"userPrincipalName", "onPremisesUserPrincipalName", "certificateUserIds",
+	}
+)
+
+// validateAuthMethodTargets validates the exclude and include targets of an
+// authentication method of the given type.
+func validateAuthMethodTargets(authMethodType string, excludeTargets []authMethodExcludeTargetModel,
+	includeTargets []authMethodIncludeTargetModel, excludePath, includePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, target := range excludeTargets {
+		if target.TargetType.IsNull() || target.TargetType.IsUnknown() {
+			continue
+		}
+		switch target.TargetType.ValueString() {
+		case "group", "user":
+		default:
+			diags.AddAttributeError(
+				excludePath,
+				"[INPUT ERROR] Invalid Exclude Target",
+				fmt.Sprintf("'%v' is invalid, only acceptable values for 'target_type' are 'group' and 'user'.",
+					target.TargetType.ValueString()),
+			)
+		}
+	}
+
+	for i, target := range includeTargets {
+		if !target.IsUsableForSignIn.IsNull() && authMethodType != "Sms" {
+			diags.AddAttributeError(
+				includePath.AtListIndex(i).AtName("is_usable_for_sign_in"),
+				"[INPUT ERROR] Invalid Include Target",
+				fmt.Sprintf("'is_usable_for_sign_in' is only supported when 'type' is 'Sms', got '%v'.",
+					authMethodType),
+			)
+		}
+
+		if target.AuthenticationMode.IsNull() || target.AuthenticationMode.IsUnknown() {
+			continue
+		}
+
+		if authMethodType != "MicrosoftAuthenticator" {
+			diags.AddAttributeError(
+				includePath.AtListIndex(i).AtName("authentication_mode"),
+				"[INPUT ERROR] Invalid Include Target",
+				fmt.Sprintf("'authentication_mode' is only supported when 'type' is 'MicrosoftAuthenticator', got '%v'.",
+					authMethodType),
+			)
+			continue
+		}
+
+		if mode, _ := graphModels.ParseMicrosoftAuthenticatorAuthenticationMode(target.AuthenticationMode.ValueString()); mode == nil {
+			diags.AddAttributeError(
+				includePath.AtListIndex(i).AtName("authentication_mode"),
+				"[INPUT ERROR] Invalid Include Target",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'any', 'push' and 'deviceBasedPush'.",
+					target.AuthenticationMode.ValueString()),
+			)
+		}
+	}
+
+	return diags
+}
+
+func validateX509CertificateSettings(settings *x509CertificateSettingsModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	settingsPath := path.Root("x509_certificate_settings")
+
+	isSet := func(value types.String) bool {
+		return !value.IsNull() && !value.IsUnknown()
+	}
+
+	validateAuthMode := func(attrPath path.Path, value types.String) {
+		if !isSet(value) {
+			return
+		}
+		if mode, _ := graphModels.ParseX509CertificateAuthenticationMode(value.ValueString()); mode == nil {
+			diags.AddAttributeError(
+				attrPath,
+				"[INPUT ERROR] Invalid X509 Certificate Settings",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'x509CertificateSingleFactor' "+
+					"and 'x509CertificateMultiFactor'.", value.ValueString()),
+			)
+		}
+	}
+
+	validateAffinityLevel := func(attrPath path.Path, value types.String) {
+		if !isSet(value) {
+			return
+		}
+		if level, _ := graphModels.ParseX509CertificateAffinityLevel(value.ValueString()); level == nil {
+			diags.AddAttributeError(
+				attrPath,
+				"[INPUT ERROR] Invalid X509 Certificate Settings",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'low' and 'high'.",
+					value.ValueString()),
+			)
+		}
+	}
+
+	validateAuthMode(settingsPath.AtName("default_authentication_mode"), settings.DefaultAuthenticationMode)
+	validateAffinityLevel(settingsPath.AtName("default_required_affinity_level"), settings.DefaultRequiredAffinityLevel)
+
+	priorities := map[int64]bool{}
+	for i, binding := range settings.UserBindings {
+		bindingPath := settingsPath.AtName("user_binding").AtListIndex(i)
+
+		if !binding.Priority.IsNull() && !binding.Priority.IsUnknown() {
+			if priorities[binding.Priority.ValueInt64()] {
+				diags.AddAttributeError(
+					bindingPath.AtName("priority"),
+					"[INPUT ERROR] Invalid X509 Certificate Settings",
+					fmt.Sprintf("Priority %d is used by more than one user binding.", binding.Priority.ValueInt64()),
+				)
+			}
+			priorities[binding.Priority.ValueInt64()] = true
+		}
+		if isSet(binding.X509CertificateField) && !slices.Contains(x509CertificateFields, binding.X509CertificateField.ValueString()) {
+			diags.AddAttributeError(
+				bindingPath.AtName("x509_certificate_field"),
+				"[INPUT ERROR] Invalid X509 Certificate Settings",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are '%v'.",
+					binding.X509CertificateField.ValueString(), strings.Join(x509CertificateFields, "', '")),
+			)
+		}
+		if isSet(binding.UserProperty) && !slices.Contains(x509CertificateUserProperties, binding.UserProperty.ValueString()) {
+			diags.AddAttributeError(
+				bindingPath.AtName("user_property"),
+				"[INPUT ERROR] Invalid X509 Certificate Settings",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are '%v'.",
+					binding.UserProperty.ValueString(), strings.Join(x509CertificateUserProperties, "', '")),
+			)
+		}
+		validateAffinityLevel(bindingPath.AtName("trust_affinity_level"), binding.TrustAffinityLevel)
+	}
+
+	for i, rule := range settings.Rules {
+		rulePath := settingsPath.AtName("rule").AtListIndex(i)
+
+		validateAuthMode(rulePath.AtName("authentication_mode"), rule.AuthenticationMode)
+		validateAffinityLevel(rulePath.AtName("required_affinity_level"), rule.RequiredAffinityLevel)
+
+		if !isSet(rule.Type) {
+			continue
+		}
+
+		switch rule.Type.ValueString() {
+		case "issuerSubject", "policyOID":
+			if rule.Identifier.IsNull() {
+				diags.AddAttributeError(
+					rulePath.AtName("identifier"),
+					"[INPUT ERROR] Invalid X509 Certificate Settings",
+					fmt.Sprintf("'identifier' is required when 'type' is '%v'.", rule.Type.ValueString()),
+				)
+			}
+			if !rule.IssuerSubjectIdentifier.IsNull() || !rule.PolicyOidIdentifier.IsNull() {
+				diags.AddAttributeError(
+					rulePath,
+					"[INPUT ERROR] Invalid X509 Certificate Settings",
+					fmt.Sprintf("'issuer_subject_identifier' and 'policy_oid_identifier' are only supported "+
+						"when 'type' is 'issuerSubjectAndPolicyOID', got '%v'.", rule.Type.ValueString()),
+				)
+			}
+		case "issuerSubjectAndPolicyOID":
+			if rule.IssuerSubjectIdentifier.IsNull() || rule.PolicyOidIdentifier.IsNull() {
+				diags.AddAttributeError(
+					rulePath,
+					"[INPUT ERROR] Invalid X509 Certificate Settings",
+					"'issuer_subject_identifier' and 'policy_oid_identifier' are required when "+
+						"'type' is 'issuerSubjectAndPolicyOID'.",
+				)
+			}
+			if !rule.Identifier.IsNull() {
+				diags.AddAttributeError(
+					rulePath.AtName("identifier"),
+					"[INPUT ERROR] Invalid X509 Certificate Settings",
+					"'identifier' is not supported when 'type' is 'issuerSubjectAndPolicyOID'.",
+				)
+			}
+		default:
+			diags.AddAttributeError(
+				rulePath.AtName("type"),
+				"[INPUT ERROR] Invalid X509 Certificate Settings",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'issuerSubject', 'policyOID' "+
+					"and 'issuerSubjectAndPolicyOID'.", rule.Type.ValueString()),
+			)
+		}
+	}
+
+	return diags
+}
+
+func setX509CertificateSettings(config graphModels.X509CertificateAuthenticationMethodConfigurationable, settings *x509CertificateSettingsModel) {
+	if len(settings.UserBindings) > 0 {
+		userBindings := []graphModels.X509CertificateUserBindingable{}
+		for _, binding := range settings.UserBindings {
+			priority := int32(binding.Priority.ValueInt64())
+
+			userBinding := graphModels.NewX509CertificateUserBinding()
+			userBinding.SetPriority(&priority)
+			userBinding.SetX509CertificateField(StringPtr(binding.X509CertificateField.ValueString()))
+			userBinding.SetUserProperty(StringPtr(binding.UserProperty.ValueString()))
+			if level, _ := graphModels.ParseX509CertificateAffinityLevel(binding.TrustAffinityLevel.ValueString()); level != nil {
+				userBinding.SetTrustAffinityLevel(level.(*graphModels.X509CertificateAffinityLevel))
+			}
+			userBindings = append(userBindings, userBinding)
+		}
+		config.SetCertificateUserBindings(userBindings)
+	}
+
+	rules := []graphModels.X509CertificateRuleable{}
+	for _, ruleModel := range settings.Rules {
+		rule := graphModels.NewX509CertificateRule()
+		if ruleType, _ := graphModels.ParseX509CertificateRuleType(ruleModel.Type.ValueString()); ruleType != nil {
+			rule.SetX509CertificateRuleType(ruleType.(*graphModels.X509CertificateRuleType))
+		}
+		rule.SetIdentifier(ruleModel.Identifier.ValueStringPointer())
+		rule.SetIssuerSubjectIdentifier(ruleModel.IssuerSubjectIdentifier.ValueStringPointer())
+		rule.SetPolicyOidIdentifier(ruleModel.PolicyOidIdentifier.ValueStringPointer())
+		if mode, _ := graphModels.ParseX509CertificateAuthenticationMode(ruleModel.AuthenticationMode.ValueString()); mode != nil {
+			rule.SetX509CertificateAuthenticationMode(mode.(*graphModels.X509CertificateAuthenticationMode))
+		}
+		if level, _ := graphModels.ParseX509CertificateAffinityLevel(ruleModel.RequiredAffinityLevel.ValueString()); level != nil {
+			rule.SetX509CertificateRequiredAffinityLevel(level.(*graphModels.X509CertificateAffinityLevel))
+		}
+		rules = append(rules, rule)
+	}
+
+	modeConfig := graphModels.NewX509CertificateAuthenticationModeConfiguration()
+	if mode, _ := graphModels.ParseX509CertificateAuthenticationMode(settings.DefaultAuthenticationMode.ValueString()); mode != nil {
+		modeConfig.SetX509CertificateAuthenticationDefaultMode(mode.(*graphModels.X509CertificateAuthenticationMode))
+	}
+	if level, _ := graphModels.ParseX509CertificateAffinityLevel(settings.DefaultRequiredAffinityLevel.ValueString()); level != nil {
+		modeConfig.SetX509CertificateDefaultRequiredAffinityLevel(level.(*graphModels.X509CertificateAffinityLevel))
+	}
+	modeConfig.SetRules(rules)
+	config.SetAuthenticationModeConfiguration(modeConfig)
+}
+
+// getX509CertificateUserBindings converts every certificate user binding into
+// the resource model, in the order returned by Graph API.
+func getX509CertificateUserBindings(config graphModels.X509CertificateAuthenticationMethodConfigurationable) []x509CertificateUserBindingModel {
+	userBindings := []x509CertificateUserBindingModel{}
+	for _, userBinding := range config.GetCertificateUserBindings() {
+		binding := x509CertificateUserBindingModel{
+			Priority:             types.Int64Null(),
+			X509CertificateField: types.StringPointerValue(userBinding.GetX509CertificateField()),
+			UserProperty:         types.StringPointerValue(userBinding.GetUserProperty()),
+			TrustAffinityLevel:   types.StringNull(),
+		}
+		if userBinding.GetPriority() != nil {
+			binding.Priority = types.Int64Value(int64(*userBinding.GetPriority()))
+		}
+		if userBinding.GetTrustAffinityLevel() != nil {
+			binding.TrustAffinityLevel = types.StringValue(userBinding.GetTrustAffinityLevel().String())
+		}
+		userBindings = append(userBindings, binding)
+	}
+	return userBindings
+}
+
+func getX509CertificateSettings(config graphModels.X509CertificateAuthenticationMethodConfigurationable, priorSettings *x509CertificateSettingsModel) *x509CertificateSettingsModel {
+	settings := &x509CertificateSettingsModel{
+		DefaultAuthenticationMode:    types.StringNull(),
+		DefaultRequiredAffinityLevel: types.StringNull(),
+		Rules:                        []x509CertificateRuleModel{},
+	}
+
+	// User bindings are ordered following the priorities in the prior state.
+	if len(priorSettings.UserBindings) > 0 {
+		userBindings := getX509CertificateUserBindings(config)
+
+		priorityIndex := func(priority types.Int64) int {
+			for i, priorBinding := range priorSettings.UserBindings {
+				if priorBinding.Priority.Equal(priority) {
+					return i
+				}
+			}
+			return len(priorSettings.UserBindings)
+		}
+		slices.SortStableFunc(userBindings, func(a, b x509CertificateUserBindingModel) int {
+			return priorityIndex(a.Priority) - priorityIndex(b.Priority)
+		})
+
+		settings.UserBindings = userBindings
+	}
+
+	modeConfig := config.GetAuthenticationModeConfiguration()
+	if modeConfig == nil {
+		return settings
+	}
+
+	if modeConfig.GetX509CertificateAuthenticationDefaultMode() != nil {
+		settings.DefaultAuthenticationMode = types.StringValue(modeConfig.GetX509CertificateAuthenticationDefaultMode().String())
+	}
+	if modeConfig.GetX509CertificateDefaultRequiredAffinityLevel() != nil {
+		settings.DefaultRequiredAffinityLevel = types.StringValue(modeConfig.GetX509CertificateDefaultRequiredAffinityLevel().String())
+	}
+
+	// Graph API may return empty identifiers for the ones not used by the rule type.
+	identifier := func(value *string) types.String {
+		if value == nil || *value == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(*value)
+	}
+
+	for _, rule := range modeConfig.GetRules() {
+		ruleModel := x509CertificateRuleModel{
+			Type:                    types.StringNull(),
+			Identifier:              identifier(rule.GetIdentifier()),
+			IssuerSubjectIdentifier: identifier(rule.GetIssuerSubjectIdentifier()),
+			PolicyOidIdentifier:     identifier(rule.GetPolicyOidIdentifier()),
+			AuthenticationMode:      types.StringNull(),
+			RequiredAffinityLevel:   types.StringNull(),
+		}
+		if rule.GetX509CertificateRuleType() != nil {
+			ruleModel.Type = types.StringValue(rule.GetX509CertificateRuleType().String())
+		}
+		if rule.GetX509CertificateAuthenticationMode() != nil {
+			ruleModel.AuthenticationMode = types.StringValue(rule.GetX509CertificateAuthenticationMode().String())
+		}
+		if rule.GetX509CertificateRequiredAffinityLevel() != nil {
+			ruleModel.RequiredAffinityLevel = types.StringValue(rule.GetX509CertificateRequiredAffinityLevel().String())
+		}
+		settings.Rules = append(settings.Rules, ruleModel)
+	}
+
+	return settings
+}
+
+func getQRCodePinSettings(config graphModels.AuthenticationMethodConfigurationable) *qrCodePinSettingsModel {
+	settings := &qrCodePinSettingsModel{
+		PinLength:                    types.Int64Null(),
+		StandardQRCodeLifetimeInDays: types.Int64Null(),
+	}
+
+	additionalData := config.GetAdditionalData()
+	if pinLength, ok := getRawInt64(additionalData["pinLength"]); ok {
+		settings.PinLength = types.Int64Value(pinLength)
+	}
+	if lifetime, ok := getRawInt64(additionalData["standardQRCodeLifetimeInDays"]); ok {
+		settings.StandardQRCodeLifetimeInDays = types.Int64Value(lifetime)
+	}
+
+	return settings
+}
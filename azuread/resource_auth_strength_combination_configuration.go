@@ -0,0 +1,590 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+var (
+	_ resource.Resource                   = &authStrengthCombinationConfigurationResource{}
+	_ resource.ResourceWithConfigure      = &authStrengthCombinationConfigurationResource{}
+	_ resource.ResourceWithValidateConfig = &authStrengthCombinationConfigurationResource{}
+	_ resource.ResourceWithModifyPlan     = &authStrengthCombinationConfigurationResource{}
+	_ resource.ResourceWithImportState    = &authStrengthCombinationConfigurationResource{}
+)
+
+func NewAuthStrengthCombinationConfigurationResource() resource.Resource {
+	return &authStrengthCombinationConfigurationResource{}
+}
+
+type authStrengthCombinationConfigurationResource struct {
+	client *graph.GraphServiceClient
+}
+
+type authStrengthCombinationConfigurationResourceModel struct {
+	ID                    types.String                                  `tfsdk:"id"`
+	PolicyID              types.String                                  `tfsdk:"policy_id"`
+	AppliesToCombinations types.Set                                     `tfsdk:"applies_to_combinations"`
+	Fido2                 *fido2CombinationConfigurationModel           `tfsdk:"fido2"`
+	X509Certificate       *x509CertificateCombinationConfigurationModel `tfsdk:"x509_certificate"`
+}
+
+type fido2CombinationConfigurationModel struct {
+	AllowedAAGUIDs types.Set `tfsdk:"allowed_aaguids"`
+}
+
+type x509CertificateCombinationConfigurationModel struct {
+	AllowedIssuerSkis types.Set `tfsdk:"allowed_issuer_skis"`
+	AllowedPolicyOIDs types.Set `tfsdk:"allowed_policy_oids"`
+}
+
+// The combinations each type of combination configuration can apply to.
+var (
+	fido2ConfigurationCombinations           = []string{"fido2"}
+	x509CertificateConfigurationCombinations = []string{"x509CertificateMultiFactor", "x509CertificateSingleFactor"}
+)
+
+func (r *authStrengthCombinationConfigurationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_strength_combination_configuration"
+}
+
+func (r *authStrengthCombinationConfigurationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a combination configuration of a custom authentication strength policy, " +
+			"restricting the passkeys (FIDO2) or certificates that satisfy the policy. Exactly one of " +
+			"`fido2` or `x509_certificate` must be configured.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the combination configuration.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "The ID of the authentication strength policy, with or without the " +
+					"`/policies/authenticationStrengthPolicies/` prefix.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"applies_to_combinations": schema.SetAttribute{
+				Description: "The allowed combinations of the authentication strength policy the " +
+					"configuration applies to. Must be `fido2` for `fido2`, and `x509CertificateMultiFactor` " +
+					"or `x509CertificateSingleFactor` for `x509_certificate`.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"fido2": schema.SingleNestedBlock{
+				Description: "Restricts the passkeys (FIDO2) that satisfy the authentication strength policy.",
+				Attributes: map[string]schema.Attribute{
+					"allowed_aaguids": schema.SetAttribute{
+						Description: "The AAGUIDs of the passkeys (FIDO2) that satisfy the authentication " +
+							"strength policy.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"x509_certificate": schema.SingleNestedBlock{
+				Description: "Restricts the certificates that satisfy the authentication strength policy. " +
+					"At least one of `allowed_issuer_skis` or `allowed_policy_oids` must be specified.",
+				Attributes: map[string]schema.Attribute{
+					"allowed_issuer_skis": schema.SetAttribute{
+						Description: "The subject key identifiers of the certificate issuers that satisfy " +
+							"the authentication strength policy.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"allowed_policy_oids": schema.SetAttribute{
+						Description: "The policy OIDs of the certificates that satisfy the authentication " +
+							"strength policy.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *authStrengthCombinationConfigurationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(azureadClients).graphClient
+}
+
+func (r *authStrengthCombinationConfigurationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config authStrengthCombinationConfigurationResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.PolicyID.IsNull() && !config.PolicyID.IsUnknown() &&
+		!guidRegex.MatchString(getAuthStrengthPolicyID(config.PolicyID.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policy_id"),
+			"[INPUT ERROR] Invalid Input",
+			fmt.Sprintf("'%v' is not a valid authentication strength policy ID.", config.PolicyID.ValueString()),
+		)
+	}
+
+	if (config.Fido2 == nil) == (config.X509Certificate == nil) {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Input",
+			"Exactly one of 'fido2' or 'x509_certificate' must be configured.",
+		)
+		return
+	}
+
+	allowedCombinations := fido2ConfigurationCombinations
+	blockName := "fido2"
+	if config.X509Certificate != nil {
+		allowedCombinations = x509CertificateConfigurationCombinations
+		blockName = "x509_certificate"
+	}
+
+	if !config.AppliesToCombinations.IsNull() && !config.AppliesToCombinations.IsUnknown() {
+		if len(config.AppliesToCombinations.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("applies_to_combinations"),
+				"[INPUT ERROR] Invalid Input",
+				"At least one combination must be specified.",
+			)
+		}
+
+		for _, element := range config.AppliesToCombinations.Elements() {
+			combination, ok := element.(types.String)
+			if !ok || combination.IsUnknown() {
+				continue
+			}
+			if !slices.Contains(allowedCombinations, combination.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("applies_to_combinations"),
+					"[INPUT ERROR] Invalid Input",
+					fmt.Sprintf("'%v' is invalid, '%v' can only apply to: %v", combination.ValueString(),
+						blockName, strings.Join(allowedCombinations, ", ")),
+				)
+			}
+		}
+	}
+
+	if config.Fido2 != nil && !config.Fido2.AllowedAAGUIDs.IsUnknown() {
+		if len(config.Fido2.AllowedAAGUIDs.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("fido2").AtName("allowed_aaguids"),
+				"[INPUT ERROR] Invalid Input",
+				"At least one AAGUID must be specified.",
+			)
+		}
+
+		for _, element := range config.Fido2.AllowedAAGUIDs.Elements() {
+			aaguid, ok := element.(types.String)
+			if ok && !aaguid.IsUnknown() && !guidRegex.MatchString(aaguid.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("fido2").AtName("allowed_aaguids"),
+					"[INPUT ERROR] Invalid Input",
+					fmt.Sprintf("'%v' is not a valid AAGUID.", aaguid.ValueString()),
+				)
+			}
+		}
+	}
+
+	if config.X509Certificate != nil &&
+		!config.X509Certificate.AllowedIssuerSkis.IsUnknown() && !config.X509Certificate.AllowedPolicyOIDs.IsUnknown() &&
+		len(config.X509Certificate.AllowedIssuerSkis.Elements()) == 0 &&
+		len(config.X509Certificate.AllowedPolicyOIDs.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("x509_certificate"),
+			"[INPUT ERROR] Invalid Input",
+			"At least one of 'allowed_issuer_skis' or 'allowed_policy_oids' must be specified.",
+		)
+	}
+}
+
+// ModifyPlan replaces the combination configuration when its type changes, as
+// the type of a combination configuration cannot be updated.
+func (r *authStrengthCombinationConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state authStrengthCombinationConfigurationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (plan.Fido2 == nil) != (state.Fido2 == nil) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("fido2"), path.Root("x509_certificate"))
+	}
+}
+
+func (r *authStrengthCombinationConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan authStrengthCombinationConfigurationResourceModel
+	var configuration graphModels.AuthenticationCombinationConfigurationable
+	var err error
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestBody, getBodyDiags := getCombinationConfigurationReqBody(&plan)
+	resp.Diagnostics.Append(getBodyDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createCombinationConfiguration := func() error {
+		configuration, err = r.client.Policies().AuthenticationStrengthPolicies().
+			ByAuthenticationStrengthPolicyId(getAuthStrengthPolicyID(plan.PolicyID.ValueString())).
+			CombinationConfigurations().Post(context.Background(), requestBody, nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(createCombinationConfiguration, reconnectBackoff)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Create Authentication Strength Combination Configuration",
+			"An unexpected error occurred while creating the Combination Configuration of the "+
+				"Authentication Strength Policy on Microsoft Entra ID via Microsoft Graph API. Please "+
+				"verify that the provided inputs are correct and that the API permissions are "+
+				"correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+
+	state := plan
+	refreshDiags := refreshCombinationConfiguration(configuration, &state)
+	resp.Diagnostics.Append(refreshDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authStrengthCombinationConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state authStrengthCombinationConfigurationResourceModel
+	var configuration graphModels.AuthenticationCombinationConfigurationable
+	var err error
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getCombinationConfiguration := func() error {
+		configuration, err = r.client.Policies().AuthenticationStrengthPolicies().
+			ByAuthenticationStrengthPolicyId(getAuthStrengthPolicyID(state.PolicyID.ValueString())).
+			CombinationConfigurations().ByAuthenticationCombinationConfigurationId(state.ID.ValueString()).
+			Get(context.Background(), nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(getCombinationConfiguration, reconnectBackoff)
+
+	if isNotFoundError(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Read Authentication Strength Combination Configuration",
+			"An unexpected error occurred while retrieving the Combination Configuration of the "+
+				"Authentication Strength Policy from Microsoft Entra ID via Microsoft Graph API. Please "+
+				"verify that the provided inputs are correct and that the API permissions are "+
+				"correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+
+	refreshDiags := refreshCombinationConfiguration(configuration, &state)
+	resp.Diagnostics.Append(refreshDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authStrengthCombinationConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state authStrengthCombinationConfigurationResourceModel
+	var err error
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestBody, getBodyDiags := getCombinationConfigurationReqBody(&plan)
+	resp.Diagnostics.Append(getBodyDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	patchCombinationConfiguration := func() error {
+		_, err = r.client.Policies().AuthenticationStrengthPolicies().
+			ByAuthenticationStrengthPolicyId(getAuthStrengthPolicyID(state.PolicyID.ValueString())).
+			CombinationConfigurations().ByAuthenticationCombinationConfigurationId(state.ID.ValueString()).
+			Patch(context.Background(), requestBody, nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(patchCombinationConfiguration, reconnectBackoff)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Update Authentication Strength Combination Configuration",
+			"An unexpected error occurred while updating the Combination Configuration of the "+
+				"Authentication Strength Policy on Microsoft Entra ID via Microsoft Graph API. Please "+
+				"verify that the provided inputs are correct and that the API permissions are "+
+				"correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authStrengthCombinationConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state authStrengthCombinationConfigurationResourceModel
+	var err error
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteCombinationConfiguration := func() error {
+		err = r.client.Policies().AuthenticationStrengthPolicies().
+			ByAuthenticationStrengthPolicyId(getAuthStrengthPolicyID(state.PolicyID.ValueString())).
+			CombinationConfigurations().ByAuthenticationCombinationConfigurationId(state.ID.ValueString()).
+			Delete(context.Background(), nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(deleteCombinationConfiguration, reconnectBackoff)
+
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Delete Authentication Strength Combination Configuration",
+			"An unexpected error occurred while deleting the Combination Configuration of the "+
+				"Authentication Strength Policy from Microsoft Entra ID via Microsoft Graph API. Please "+
+				"verify that the API permissions are correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the combination configuration with the ID in the
+// format '<policy ID>/<combination configuration ID>'.
+func (r *authStrengthCombinationConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	separatorIndex := strings.LastIndex(req.ID, "/")
+	if separatorIndex < 0 {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Import ID",
+			fmt.Sprintf("'%v' is invalid, the combination configuration can only be imported with the "+
+				"ID in the format '<policy ID>/<combination configuration ID>'.", req.ID),
+		)
+		return
+	}
+
+	policyID := getAuthStrengthPolicyID(req.ID[:separatorIndex])
+	if !guidRegex.MatchString(policyID) {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Import ID",
+			fmt.Sprintf("'%v' is not a valid authentication strength policy ID.", req.ID[:separatorIndex]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), policyID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID[separatorIndex+1:])...)
+}
+
+func getCombinationConfigurationReqBody(plan *authStrengthCombinationConfigurationResourceModel) (graphModels.AuthenticationCombinationConfigurationable, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var requestBody graphModels.AuthenticationCombinationConfigurationable
+
+	if plan.Fido2 != nil {
+		aaguids, err := setOfStringsToSlice(plan.Fido2.AllowedAAGUIDs)
+		if err != nil {
+			diags.AddError("[INPUT ERROR] Invalid Input", err.Error())
+			return nil, diags
+		}
+
+		fido2Configuration := graphModels.NewFido2CombinationConfiguration()
+		fido2Configuration.SetAllowedAAGUIDs(aaguids)
+		requestBody = fido2Configuration
+	} else {
+		issuerSkis, err := setOfStringsToSlice(plan.X509Certificate.AllowedIssuerSkis)
+		if err != nil {
+			diags.AddError("[INPUT ERROR] Invalid Input", err.Error())
+			return nil, diags
+		}
+		policyOIDs, err := setOfStringsToSlice(plan.X509Certificate.AllowedPolicyOIDs)
+		if err != nil {
+			diags.AddError("[INPUT ERROR] Invalid Input", err.Error())
+			return nil, diags
+		}
+
+		x509CertificateConfiguration := graphModels.NewX509CertificateCombinationConfiguration()
+		x509CertificateConfiguration.SetAllowedIssuerSkis(issuerSkis)
+		x509CertificateConfiguration.SetAllowedPolicyOIDs(policyOIDs)
+		requestBody = x509CertificateConfiguration
+	}
+
+	appliesToCombinations, getCombinationsDiags := getAllowedCombinations(plan.AppliesToCombinations)
+	diags.Append(getCombinationsDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	requestBody.SetAppliesToCombinations(appliesToCombinations)
+
+	return requestBody, diags
+}
+
+// refreshCombinationConfiguration refreshes the state from the combination
+// configuration. The block of its type is always set, so that an imported
+// combination configuration is read in full.
+func refreshCombinationConfiguration(configuration graphModels.AuthenticationCombinationConfigurationable,
+	state *authStrengthCombinationConfigurationResourceModel) diag.Diagnostics {
+	var diags, setDiags diag.Diagnostics
+
+	priorCombinations, err := setOfStringsToSlice(state.AppliesToCombinations)
+	if err != nil {
+		diags.AddError("[INPUT ERROR] Invalid Input", err.Error())
+		return diags
+	}
+
+	state.ID = types.StringValue(*configuration.GetId())
+	state.AppliesToCombinations, setDiags = types.SetValue(types.StringType,
+		getCombinationValues(configuration.GetAppliesToCombinations(), priorCombinations))
+	diags.Append(setDiags...)
+
+	switch typedConfiguration := configuration.(type) {
+	case graphModels.Fido2CombinationConfigurationable:
+		var priorAAGUIDs types.Set
+		if state.Fido2 != nil {
+			priorAAGUIDs = state.Fido2.AllowedAAGUIDs
+		}
+		state.Fido2 = &fido2CombinationConfigurationModel{
+			AllowedAAGUIDs: getCaseInsensitiveSetValue(typedConfiguration.GetAllowedAAGUIDs(), priorAAGUIDs),
+		}
+		state.X509Certificate = nil
+	case graphModels.X509CertificateCombinationConfigurationable:
+		var priorIssuerSkis, priorPolicyOIDs types.Set
+		if state.X509Certificate != nil {
+			priorIssuerSkis = state.X509Certificate.AllowedIssuerSkis
+			priorPolicyOIDs = state.X509Certificate.AllowedPolicyOIDs
+		}
+		state.X509Certificate = &x509CertificateCombinationConfigurationModel{
+			AllowedIssuerSkis: getCaseInsensitiveSetValue(typedConfiguration.GetAllowedIssuerSkis(), priorIssuerSkis),
+			AllowedPolicyOIDs: getCaseInsensitiveSetValue(typedConfiguration.GetAllowedPolicyOIDs(), priorPolicyOIDs),
+		}
+		state.Fido2 = nil
+	default:
+		diags.AddError(
+			"[API ERROR] Unsupported Combination Configuration",
+			fmt.Sprintf("The combination configuration '%v' is of an unsupported type.", *configuration.GetId()),
+		)
+	}
+
+	return diags
+}
+
+// getCaseInsensitiveSetValue converts the values to a set, keeping the prior
+// values that only differ in case. A null prior set stays null when there are
+// no values.
+func getCaseInsensitiveSetValue(values []string, prior types.Set) types.Set {
+	if len(values) == 0 && prior.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+
+	priorValues, _ := setOfStringsToSlice(prior)
+
+	elements := []attr.Value{}
+	for _, value := range values {
+		for _, priorValue := range priorValues {
+			if strings.EqualFold(priorValue, value) {
+				value = priorValue
+				break
+			}
+		}
+		elements = append(elements, types.StringValue(value))
+	}
+
+	return types.SetValueMust(types.StringType, elements)
+}
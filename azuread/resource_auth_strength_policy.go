@@ -0,0 +1,688 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+	graphPolicies "github.com/microsoftgraph/msgraph-sdk-go/policies"
+)
+
+var (
+	_ resource.Resource                   = &authStrengthPolicyResource{}
+	_ resource.ResourceWithConfigure      = &authStrengthPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &authStrengthPolicyResource{}
+	_ resource.ResourceWithModifyPlan     = &authStrengthPolicyResource{}
+	_ resource.ResourceWithImportState    = &authStrengthPolicyResource{}
+)
+
+func NewAuthStrengthPolicyResource() resource.Resource {
+	return &authStrengthPolicyResource{}
+}
+
+type authStrengthPolicyResource struct {
+	client *graph.GraphServiceClient
+}
+
+type authStrengthPolicyResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	PathID              types.String `tfsdk:"path_id"`
+	DisplayName         types.String `tfsdk:"display_name"`
+	Description         types.String `tfsdk:"description"`
+	AllowedCombinations types.Set    `tfsdk:"allowed_combinations"`
+}
+
+// The prefix of the authentication strength policy IDs referenced by the
+// conditional access policies.
+const authStrengthPolicyPathPrefix = "/policies/authenticationStrengthPolicies/"
+
+func (r *authStrengthPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_strength_policy"
+}
+
+func (r *authStrengthPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom authentication strength policy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the authentication strength policy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path_id": schema.StringAttribute{
+				Description: "The ID of the authentication strength policy prefixed with " +
+					"`/policies/authenticationStrengthPolicies/`, as referenced by the conditional access policies.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Description: "The name of the authentication strength policy.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the authentication strength policy.",
+				Optional:    true,
+			},
+			"allowed_combinations": schema.SetAttribute{
+				Description: "The authentication method combinations allowed by the authentication strength " +
+					"policy. Each combination is a comma separated list of authentication method modes, " +
+					"e.g. `fido2` or `password,microsoftAuthenticatorPush`.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *authStrengthPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(azureadClients).graphClient
+}
+
+func (r *authStrengthPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config authStrengthPolicyResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.AllowedCombinations.IsNull() || config.AllowedCombinations.IsUnknown() {
+		return
+	}
+
+	if len(config.AllowedCombinations.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allowed_combinations"),
+			"[INPUT ERROR] Invalid Input",
+			"At least one allowed combination must be specified.",
+		)
+		return
+	}
+
+	seenCombinations := map[graphModels.AuthenticationMethodModes]string{}
+	for _, element := range config.AllowedCombinations.Elements() {
+		combination, ok := element.(types.String)
+		if !ok || combination.IsUnknown() {
+			continue
+		}
+
+		modes, err := getAuthMethodModes(combination.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("allowed_combinations"),
+				"[INPUT ERROR] Invalid Input",
+				fmt.Sprintf("'%v' is an invalid combination, %v.", combination.ValueString(), err),
+			)
+			continue
+		}
+
+		if otherCombination, ok := seenCombinations[modes]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("allowed_combinations"),
+				"[INPUT ERROR] Invalid Input",
+				fmt.Sprintf("'%v' and '%v' are the same combination.", otherCombination, combination.ValueString()),
+			)
+			continue
+		}
+		seenCombinations[modes] = combination.ValueString()
+	}
+}
+
+// ModifyPlan validates the allowed combinations against the authentication
+// method modes available on the tenant.
+func (r *authStrengthPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan authStrengthPolicyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AllowedCombinations.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state authStrengthPolicyResourceModel
+		getStateDiags := req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(getStateDiags...)
+		if resp.Diagnostics.HasError() || plan.AllowedCombinations.Equal(state.AllowedCombinations) {
+			return
+		}
+	}
+
+	combinations, err := setOfStringsToSlice(plan.AllowedCombinations)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Input",
+			err.Error(),
+		)
+		return
+	}
+
+	modeDetails, getModesDiags := getAuthMethodModeDetails(r.client)
+	resp.Diagnostics.Append(getModesDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	availableModes := []string{}
+	for _, mode := range modeDetails {
+		availableModes = append(availableModes, *mode.GetId())
+	}
+
+	for _, combination := range combinations {
+		for _, mode := range splitAuthMethodModes(combination) {
+			if !slices.Contains(availableModes, mode) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("allowed_combinations"),
+					"[INPUT ERROR] Invalid Input",
+					fmt.Sprintf("'%v' in the combination '%v' is not an authentication method mode "+
+						"available on the tenant. Available modes are: %v", mode, combination,
+						strings.Join(availableModes, ", ")),
+				)
+			}
+		}
+	}
+}
+
+func (r *authStrengthPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan authStrengthPolicyResourceModel
+	var policy graphModels.AuthenticationStrengthPolicyable
+	var err error
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	allowedCombinations, getCombinationsDiags := getAllowedCombinations(plan.AllowedCombinations)
+	resp.Diagnostics.Append(getCombinationsDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestBody := graphModels.NewAuthenticationStrengthPolicy()
+	requestBody.SetDisplayName(plan.DisplayName.ValueStringPointer())
+	requestBody.SetDescription(plan.Description.ValueStringPointer())
+	requestBody.SetAllowedCombinations(allowedCombinations)
+
+	createAuthStrengthPolicy := func() error {
+		policy, err = r.client.Policies().AuthenticationStrengthPolicies().Post(context.Background(), requestBody, nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(createAuthStrengthPolicy, reconnectBackoff)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Create Authentication Strength Policy",
+			"An unexpected error occurred while creating the Authentication Strength Policy "+
+				"on Microsoft Entra ID via Microsoft Graph API. Please verify that the provided "+
+				"inputs are correct and that the API permissions are "+
+				"correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+
+	state := plan
+	refreshDiags := refreshAuthStrengthPolicy(policy, &state)
+	resp.Diagnostics.Append(refreshDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authStrengthPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state authStrengthPolicyResourceModel
+	var policy graphModels.AuthenticationStrengthPolicyable
+	var err error
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getAuthStrengthPolicy := func() error {
+		policy, err = r.client.Policies().AuthenticationStrengthPolicies().
+			ByAuthenticationStrengthPolicyId(state.ID.ValueString()).Get(context.Background(), nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(getAuthStrengthPolicy, reconnectBackoff)
+
+	if isNotFoundError(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Read Authentication Strength Policy",
+			"An unexpected error occurred while retrieving the Authentication Strength Policy "+
+				"from Microsoft Entra ID via Microsoft Graph API. Please verify that the provided "+
+				"inputs are correct and that the API permissions are "+
+				"correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+
+	if policy.GetPolicyType() != nil && *policy.GetPolicyType() == graphModels.BUILTIN_AUTHENTICATIONSTRENGTHPOLICYTYPE {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Built-in Authentication Strength Policy",
+			fmt.Sprintf("'%v' is a built-in authentication strength policy, which cannot be managed. "+
+				"Use the st-azuread_auth_strengths data source to look it up instead.", state.ID.ValueString()),
+		)
+		return
+	}
+
+	refreshDiags := refreshAuthStrengthPolicy(policy, &state)
+	resp.Diagnostics.Append(refreshDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authStrengthPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state authStrengthPolicyResourceModel
+	var err error
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyRequestBuilder := r.client.Policies().AuthenticationStrengthPolicies().
+		ByAuthenticationStrengthPolicyId(state.ID.ValueString())
+
+	// The allowed combinations cannot be changed through a PATCH request, so
+	// they are updated separately.
+	if !plan.DisplayName.Equal(state.DisplayName) || !plan.Description.Equal(state.Description) {
+		requestBody := graphModels.NewAuthenticationStrengthPolicy()
+		requestBody.SetDisplayName(plan.DisplayName.ValueStringPointer())
+		if plan.Description.IsNull() {
+			requestBody.SetDescription(StringPtr(""))
+		} else {
+			requestBody.SetDescription(plan.Description.ValueStringPointer())
+		}
+
+		patchAuthStrengthPolicy := func() error {
+			if _, err = policyRequestBuilder.Patch(context.Background(), requestBody, nil); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err = backoff.Retry(patchAuthStrengthPolicy, reconnectBackoff)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Unable to Update Authentication Strength Policy",
+				"An unexpected error occurred while updating the Authentication Strength Policy "+
+					"on Microsoft Entra ID via Microsoft Graph API. Please verify that the provided "+
+					"inputs are correct and that the API permissions are "+
+					"correctly configured.\n\n"+
+					"Microsoft Graph API Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if !plan.AllowedCombinations.Equal(state.AllowedCombinations) {
+		allowedCombinations, getCombinationsDiags := getAllowedCombinations(plan.AllowedCombinations)
+		resp.Diagnostics.Append(getCombinationsDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		requestBody := graphPolicies.NewAuthenticationStrengthPoliciesItemUpdateAllowedCombinationsPostRequestBody()
+		requestBody.SetAllowedCombinations(allowedCombinations)
+
+		updateAllowedCombinations := func() error {
+			if _, err = policyRequestBuilder.UpdateAllowedCombinations().Post(context.Background(), requestBody, nil); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err = backoff.Retry(updateAllowedCombinations, reconnectBackoff)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Unable to Update Authentication Strength Policy Combinations",
+				"An unexpected error occurred while updating the allowed combinations of the "+
+					"Authentication Strength Policy on Microsoft Entra ID via Microsoft Graph API. "+
+					"Please verify that the provided inputs are correct and that the API permissions are "+
+					"correctly configured.\n\n"+
+					"Microsoft Graph API Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	plan.PathID = state.PathID
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authStrengthPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state authStrengthPolicyResourceModel
+	var err error
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Microsoft Graph API refuses to delete an authentication strength policy
+	// that is still required by conditional access policies, so they are
+	// reported up front.
+	usage, getUsageDiags := getAuthStrengthUsage(r.client, state.ID.ValueString())
+	resp.Diagnostics.Append(getUsageDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	referencingPolicies := []string{}
+	for _, caPolicy := range slices.Concat(usage.GetMfa(), usage.GetNone()) {
+		referencingPolicies = append(referencingPolicies, fmt.Sprintf("%v (%v)",
+			getStringValue(caPolicy.GetDisplayName()), getStringValue(caPolicy.GetId())))
+	}
+	if len(referencingPolicies) > 0 {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Authentication Strength Policy In Use",
+			fmt.Sprintf("The authentication strength policy '%v' cannot be deleted, because it is still "+
+				"required by the following conditional access policies:\n\n%v",
+				state.DisplayName.ValueString(), strings.Join(referencingPolicies, "\n")),
+		)
+		return
+	}
+
+	deleteAuthStrengthPolicy := func() error {
+		err = r.client.Policies().AuthenticationStrengthPolicies().
+			ByAuthenticationStrengthPolicyId(state.ID.ValueString()).Delete(context.Background(), nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(deleteAuthStrengthPolicy, reconnectBackoff)
+
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Unable to Delete Authentication Strength Policy",
+			"An unexpected error occurred while deleting the Authentication Strength Policy "+
+				"from Microsoft Entra ID via Microsoft Graph API. Please verify that the API "+
+				"permissions are correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *authStrengthPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := getAuthStrengthPolicyID(req.ID)
+	if !guidRegex.MatchString(id) {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Import ID",
+			fmt.Sprintf("'%v' is invalid, the authentication strength policy can only be imported "+
+				"with its ID.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// refreshAuthStrengthPolicy refreshes the state from the policy. Combinations
+// that only differ from the state in the order of their modes are kept as
+// written in the state.
+func refreshAuthStrengthPolicy(policy graphModels.AuthenticationStrengthPolicyable, state *authStrengthPolicyResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	priorCombinations, err := setOfStringsToSlice(state.AllowedCombinations)
+	if err != nil {
+		diags.AddError(
+			"[INPUT ERROR] Invalid Input",
+			err.Error(),
+		)
+		return diags
+	}
+
+	state.ID = types.StringValue(*policy.GetId())
+	state.PathID = types.StringValue(authStrengthPolicyPathPrefix + *policy.GetId())
+	state.DisplayName = types.StringPointerValue(policy.GetDisplayName())
+	if policy.GetDescription() != nil && *policy.GetDescription() != "" {
+		state.Description = types.StringValue(*policy.GetDescription())
+	} else {
+		state.Description = types.StringNull()
+	}
+	state.AllowedCombinations, diags = types.SetValue(types.StringType,
+		getCombinationValues(policy.GetAllowedCombinations(), priorCombinations))
+
+	return diags
+}
+
+// getCombinationValues converts the combinations to strings, keeping the prior
+// strings of the combinations that only differ in the order of their modes.
+func getCombinationValues(combinations []graphModels.AuthenticationMethodModes, priorCombinations []string) []attr.Value {
+	values := []attr.Value{}
+	for _, combination := range combinations {
+		combinationString := combination.String()
+		for _, priorCombination := range priorCombinations {
+			if priorModes, err := getAuthMethodModes(priorCombination); err == nil && priorModes == combination {
+				combinationString = priorCombination
+				break
+			}
+		}
+		values = append(values, types.StringValue(combinationString))
+	}
+	return values
+}
+
+// getAuthStrengthPolicyID gets the ID of the authentication strength policy
+// from either its ID or its path.
+func getAuthStrengthPolicyID(id string) string {
+	return strings.TrimPrefix(id, authStrengthPolicyPathPrefix)
+}
+
+// splitAuthMethodModes splits a combination into its authentication method
+// modes.
+func splitAuthMethodModes(combination string) []string {
+	modes := []string{}
+	for _, mode := range strings.Split(combination, ",") {
+		modes = append(modes, strings.TrimSpace(mode))
+	}
+	return modes
+}
+
+// getAuthMethodModes parses a combination into the authentication method modes
+// flags of the SDK, so that combinations can be compared regardless of the
+// order of their modes.
+func getAuthMethodModes(combination string) (graphModels.AuthenticationMethodModes, error) {
+	var result graphModels.AuthenticationMethodModes
+
+	modes := splitAuthMethodModes(combination)
+	for i, mode := range modes {
+		if mode == "" {
+			return result, fmt.Errorf("the combination contains an empty mode")
+		}
+		if slices.Contains(modes[:i], mode) {
+			return result, fmt.Errorf("'%v' is repeated", mode)
+		}
+
+		parsedMode, _ := graphModels.ParseAuthenticationMethodModes(mode)
+		if parsedMode == nil || mode == "unknownFutureValue" {
+			return result, fmt.Errorf("'%v' is not a known authentication method mode", mode)
+		}
+		result |= *parsedMode.(*graphModels.AuthenticationMethodModes)
+	}
+
+	return result, nil
+}
+
+func getAllowedCombinations(set types.Set) ([]graphModels.AuthenticationMethodModes, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	combinations, err := setOfStringsToSlice(set)
+	if err != nil {
+		diags.AddError(
+			"[INPUT ERROR] Invalid Input",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	allowedCombinations := []graphModels.AuthenticationMethodModes{}
+	for _, combination := range combinations {
+		modes, err := getAuthMethodModes(combination)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("allowed_combinations"),
+				"[INPUT ERROR] Invalid Input",
+				fmt.Sprintf("'%v' is an invalid combination, %v.", combination, err),
+			)
+			continue
+		}
+		allowedCombinations = append(allowedCombinations, modes)
+	}
+
+	return allowedCombinations, diags
+}
+
+// getAuthMethodModeDetails gets the authentication method modes that can be
+// used in the authentication strength policies of the tenant.
+func getAuthMethodModeDetails(client *graph.GraphServiceClient) ([]graphModels.AuthenticationMethodModeDetailable, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var modes []graphModels.AuthenticationMethodModeDetailable
+
+	// Each page is retried by iteratePages.
+	requestBuilder := client.Identity().ConditionalAccess().AuthenticationStrength().AuthenticationMethodModes()
+	err := iteratePages(func(nextLink *string) (graphModels.AuthenticationMethodModeDetailCollectionResponseable, error) {
+		if nextLink != nil {
+			return requestBuilder.WithUrl(*nextLink).Get(context.Background(), nil)
+		}
+		return requestBuilder.Get(context.Background(), nil)
+	}, func(mode graphModels.AuthenticationMethodModeDetailable) {
+		if mode.GetId() != nil {
+			modes = append(modes, mode)
+		}
+	})
+
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Unable to Retrieve Authentication Method Modes",
+			"An unexpected error occurred while retrieving the Authentication Method Modes "+
+				"from Microsoft Entra ID via Microsoft Graph API. Please verify that the API "+
+				"permissions are correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	return modes, diags
+}
+
+// getAuthStrengthUsage gets the conditional access policies that require the
+// authentication strength policy, split into the policies that do and do not
+// satisfy MFA.
+func getAuthStrengthUsage(client *graph.GraphServiceClient, policyID string) (graphModels.AuthenticationStrengthUsageable, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var usage graphModels.AuthenticationStrengthUsageable
+	var err error
+
+	getUsage := func() error {
+		usage, err = client.Policies().AuthenticationStrengthPolicies().
+			ByAuthenticationStrengthPolicyId(policyID).Usage().Get(context.Background(), nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(getUsage, reconnectBackoff)
+
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Unable to Retrieve Authentication Strength Policy Usage",
+			"An unexpected error occurred while retrieving the conditional access policies "+
+				"requiring the Authentication Strength Policy from Microsoft Entra ID via Microsoft "+
+				"Graph API. Please verify that the provided Authentication Strength Policy ID is "+
+				"correct and that the API permissions are correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	return usage, diags
+}
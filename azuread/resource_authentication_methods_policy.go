@@ -0,0 +1,473 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+// The ID of the authentication methods policy, which is a singleton.
+const authenticationMethodsPolicyID = "authenticationMethodsPolicy"
+
+var (
+	_ resource.Resource                   = &authenticationMethodsPolicyResource{}
+	_ resource.ResourceWithConfigure      = &authenticationMethodsPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &authenticationMethodsPolicyResource{}
+	_ resource.ResourceWithImportState    = &authenticationMethodsPolicyResource{}
+)
+
+func NewAuthenticationMethodsPolicyResource() resource.Resource {
+	return &authenticationMethodsPolicyResource{}
+}
+
+type authenticationMethodsPolicyResource struct {
+	client                 *graph.GraphServiceClient
+	alwaysExcludedGroupIDs []string
+}
+
+type authenticationMethodsPolicyResourceModel struct {
+	ID      types.String                `tfsdk:"id"`
+	Methods []authenticationMethodModel `tfsdk:"method"`
+}
+
+type authenticationMethodModel struct {
+	Type           types.String                   `tfsdk:"type"`
+	ExcludeTargets []authMethodExcludeTargetModel `tfsdk:"exclude_targets"`
+	IncludeTargets []authMethodIncludeTargetModel `tfsdk:"include_target"`
+}
+
+func (r *authenticationMethodsPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authentication_methods_policy"
+}
+
+func (r *authenticationMethodsPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages every authentication method policy on Microsoft Entra ID at once. The " +
+			"declared authentication methods are enabled and every other authentication method is " +
+			"disabled. Must not be used together with `st-azuread_auth_method_policy`. Destroying the " +
+			"resource leaves the authentication methods unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the authentication methods policy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"method": schema.ListNestedBlock{
+				Description: "The authentication methods to enable.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The type of the authentication method. Possible values are " +
+								"`Email`, `Fido2`, `MicrosoftAuthenticator`, `Voice`, `Sms`, `SoftwareOath`, " +
+								"`TemporaryAccessPass`, `X509Certificate`, `HardwareOath`, `QRCodePin`",
+							Required: true,
+						},
+						"exclude_targets": authMethodExcludeTargetsAttribute(),
+					},
+					Blocks: map[string]schema.Block{
+						"include_target": authMethodIncludeTargetBlock(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *authenticationMethodsPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(azureadClients).graphClient
+	r.alwaysExcludedGroupIDs = req.ProviderData.(azureadClients).alwaysExcludedGroupIDs
+}
+
+func (r *authenticationMethodsPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config authenticationMethodsPolicyResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	declaredTypes := []string{}
+	for i, method := range config.Methods {
+		if method.Type.IsNull() || method.Type.IsUnknown() {
+			continue
+		}
+		methodPath := path.Root("method").AtListIndex(i)
+		authMethodType := method.Type.ValueString()
+
+		if !slices.Contains(authMethodTypes, authMethodType) {
+			resp.Diagnostics.AddAttributeError(
+				methodPath.AtName("type"),
+				"[INPUT ERROR] Invalid Authentication Method Type",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are '%v'.",
+					authMethodType, strings.Join(authMethodTypes, "', '")),
+			)
+			continue
+		}
+
+		if slices.Contains(declaredTypes, authMethodType) {
+			resp.Diagnostics.AddAttributeError(
+				methodPath.AtName("type"),
+				"[INPUT ERROR] Duplicated Authentication Method",
+				fmt.Sprintf("The authentication method '%v' is declared more than once.", authMethodType),
+			)
+			continue
+		}
+		declaredTypes = append(declaredTypes, authMethodType)
+
+		resp.Diagnostics.Append(validateAuthMethodTargets(authMethodType, method.ExcludeTargets,
+			method.IncludeTargets, methodPath.AtName("exclude_targets"), methodPath.AtName("include_target"))...)
+	}
+}
+
+func (r *authenticationMethodsPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan authenticationMethodsPolicyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applyDiags := r.applyAuthenticationMethodsPolicy(&plan)
+	resp.Diagnostics.Append(applyDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authenticationMethodsPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *authenticationMethodsPolicyResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authMethodConfigurations, getDiags := getAuthMethodConfigurations(r.client, authMethodTypes)
+	resp.Diagnostics.Append(getDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Declared authentication methods keep their order, the disabled ones are
+	// dropped and the undeclared enabled ones are appended, so that both show
+	// as drift.
+	methods := []authenticationMethodModel{}
+	for _, method := range state.Methods {
+		// A method that could not be read is kept as it is.
+		config, ok := authMethodConfigurations[method.Type.ValueString()]
+		if !ok {
+			methods = append(methods, method)
+			continue
+		}
+		if config == nil || config.GetState() == nil ||
+			*config.GetState() != graphModels.ENABLED_AUTHENTICATIONMETHODSTATE {
+			continue
+		}
+		methods = append(methods, r.getAuthenticationMethod(config, &method))
+	}
+
+	for _, authMethodType := range authMethodTypes {
+		config := authMethodConfigurations[authMethodType]
+		if config == nil || config.GetState() == nil ||
+			*config.GetState() != graphModels.ENABLED_AUTHENTICATIONMETHODSTATE {
+			continue
+		}
+		if slices.ContainsFunc(state.Methods, func(method authenticationMethodModel) bool {
+			return method.Type.ValueString() == authMethodType
+		}) {
+			continue
+		}
+
+		methods = append(methods, r.getAuthenticationMethod(config, &authenticationMethodModel{
+			Type: types.StringValue(authMethodType),
+		}))
+
+		// Nothing is declared yet on import.
+		if len(state.Methods) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Undeclared Authentication Method Enabled",
+				fmt.Sprintf("The authentication method '%v' is enabled on Microsoft Entra ID but is not "+
+					"declared, it will be disabled on the next apply.", authMethodType),
+			)
+		}
+	}
+
+	state.ID = types.StringValue(authenticationMethodsPolicyID)
+	state.Methods = methods
+	if len(state.Methods) == 0 {
+		state.Methods = nil
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authenticationMethodsPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan authenticationMethodsPolicyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applyDiags := r.applyAuthenticationMethodsPolicy(&plan)
+	resp.Diagnostics.Append(applyDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Disabling every authentication method would lock every user out, so the
+// authentication methods are left unchanged.
+func (r *authenticationMethodsPolicyResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"Authentication Methods Left Unchanged",
+		"The authentication methods policy is removed from the Terraform state only, the "+
+			"authentication methods on Microsoft Entra ID are left unchanged.",
+	)
+}
+
+func (r *authenticationMethodsPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != authenticationMethodsPolicyID {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Import ID",
+			fmt.Sprintf("'%v' is invalid, the authentication methods policy can only be imported "+
+				"with the ID '%v'.", req.ID, authenticationMethodsPolicyID),
+		)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// authMethodPolicy returns an authentication method policy resource sharing
+// the clients of the resource, to manage each authentication method.
+func (r *authenticationMethodsPolicyResource) authMethodPolicy() *authMethodPolicyResource {
+	return &authMethodPolicyResource{
+		client:                 r.client,
+		alwaysExcludedGroupIDs: r.alwaysExcludedGroupIDs,
+	}
+}
+
+// applyAuthenticationMethodsPolicy enables the declared authentication methods
+// first and only then disables the undeclared ones, so that users are never
+// left without the authentication methods they are moved to.
+func (r *authenticationMethodsPolicyResource) applyAuthenticationMethodsPolicy(plan *authenticationMethodsPolicyResourceModel) diag.Diagnostics {
+	authMethodPolicy := r.authMethodPolicy()
+
+	for i, method := range plan.Methods {
+		methodPlan := &authMethodPolicyResourceModel{
+			State:          types.StringValue("enabled"),
+			Type:           method.Type,
+			ExcludeTargets: method.ExcludeTargets,
+			IncludeTargets: method.IncludeTargets,
+		}
+
+		var methodState authMethodPolicyResourceModel
+		createDiags := authMethodPolicy.createAuthMethodPolicy(methodPlan, &methodState)
+		if createDiags.HasError() {
+			return createDiags
+		}
+		plan.Methods[i].IncludeTargets = methodState.IncludeTargets
+	}
+
+	authMethodConfigurations, getDiags := getAuthMethodConfigurations(r.client, authMethodTypes)
+	if getDiags.HasError() {
+		return getDiags
+	}
+
+	disabledState := graphModels.DISABLED_AUTHENTICATIONMETHODSTATE
+	for _, authMethodType := range authMethodTypes {
+		config := authMethodConfigurations[authMethodType]
+		if config == nil || config.GetState() == nil || *config.GetState() == disabledState {
+			continue
+		}
+		if slices.ContainsFunc(plan.Methods, func(method authenticationMethodModel) bool {
+			return method.Type.ValueString() == authMethodType
+		}) {
+			continue
+		}
+
+		requestBody := authMethodPolicy.getAuthMethodReqBody(&authMethodPolicyResourceModel{
+			Type: types.StringValue(authMethodType),
+		})
+		requestBody.SetState(&disabledState)
+
+		disableAuthMethod := func() error {
+			err := authMethodPolicy.patchAuthMethodConfiguration(authMethodType, requestBody)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err := backoff.Retry(disableAuthMethod, reconnectBackoff)
+
+		if err != nil {
+			return diag.Diagnostics{
+				diag.NewErrorDiagnostic(
+					"[API ERROR] Unable to Disable Authentication Method",
+					fmt.Sprintf("An unexpected error occurred while disabling the authentication method "+
+						"'%v' on Microsoft Entra ID via Microsoft Graph API. Please verify that the API "+
+						"permissions are correctly configured.\n\n"+
+						"Microsoft Graph API Error: %v", authMethodType, err.Error()),
+				),
+			}
+		}
+	}
+
+	plan.ID = types.StringValue(authenticationMethodsPolicyID)
+
+	// Keep the warnings about the authentication methods that could not be read.
+	return getDiags
+}
+
+// getAuthMethodConfigurations gets the configurations of the given
+// authentication method types, by type. The configurations are listed at once,
+// while the types with settings only in the beta schema are read one by one on
+// a best-effort basis: a type that cannot be read is reported as a warning.
+//
+// A requested type that is missing from the tenant or cannot be read maps to
+// nil, so callers must check each configuration for nil before using it.
+func getAuthMethodConfigurations(client *graph.GraphServiceClient, requestedTypes []string) (map[string]graphModels.AuthenticationMethodConfigurationable, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	authMethodConfigurations := map[string]graphModels.AuthenticationMethodConfigurationable{}
+
+	requestBuilder := client.Policies().AuthenticationMethodsPolicy().AuthenticationMethodConfigurations()
+	err := iteratePages(func(nextLink *string) (graphModels.AuthenticationMethodConfigurationCollectionResponseable, error) {
+		if nextLink != nil {
+			return requestBuilder.WithUrl(*nextLink).Get(context.Background(), nil)
+		}
+		return requestBuilder.Get(context.Background(), nil)
+	}, func(config graphModels.AuthenticationMethodConfigurationable) {
+		if config.GetId() == nil {
+			return
+		}
+		for _, authMethodType := range requestedTypes {
+			if _, ok := betaAuthMethodOdataTypes[authMethodType]; !ok && strings.EqualFold(authMethodType, *config.GetId()) {
+				authMethodConfigurations[authMethodType] = config
+			}
+		}
+	})
+
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Unable to Read Authentication Methods Policy",
+			"An unexpected error occurred while listing the authentication method configurations "+
+				"from Microsoft Entra ID via Microsoft Graph API. Please verify that the API "+
+				"permissions are correctly configured.\n\n"+
+				"Microsoft Graph API Error: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	authMethodPolicy := &authMethodPolicyResource{client: client}
+	for _, authMethodType := range requestedTypes {
+		if !isBetaAuthMethodType(authMethodType) {
+			continue
+		}
+
+		getAuthMethodConfiguration := func() error {
+			config, err := authMethodPolicy.getAuthMethodConfiguration(authMethodType)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			authMethodConfigurations[authMethodType] = config
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err := backoff.Retry(getAuthMethodConfiguration, reconnectBackoff)
+
+		// A type also listed from the v1.0 endpoint is kept without the settings
+		// only part of the beta schema.
+		if err != nil && authMethodConfigurations[authMethodType] != nil {
+			diags.AddWarning(
+				"[API ERROR] Unable to Read Authentication Method",
+				fmt.Sprintf("The authentication method '%v' could not be read from the Microsoft Graph "+
+					"API beta endpoint, so the settings only part of the beta schema are not read.\n\n"+
+					"Microsoft Graph API Error: %v", authMethodType, err.Error()),
+			)
+		} else if err != nil {
+			diags.AddWarning(
+				"[API ERROR] Unable to Read Authentication Method",
+				fmt.Sprintf("The authentication method '%v' could not be read from the Microsoft Graph "+
+					"API beta endpoint, so it is skipped. The tenant may not support it yet.\n\n"+
+					"Microsoft Graph API Error: %v", authMethodType, err.Error()),
+			)
+		}
+	}
+
+	return authMethodConfigurations, diags
+}
+
+// getAuthenticationMethod converts an authentication method configuration into
+// the resource model. The provider-wide excluded groups are hidden unless they
+// are declared, and the include targets are only refreshed when managed.
+func (r *authenticationMethodsPolicyResource) getAuthenticationMethod(config graphModels.AuthenticationMethodConfigurationable,
+	priorMethod *authenticationMethodModel) authenticationMethodModel {
+	excludeTargets := slices.DeleteFunc(getExcludeTargets(config), func(target authMethodExcludeTargetModel) bool {
+		return slices.Contains(r.alwaysExcludedGroupIDs, target.ID.ValueString()) &&
+			!slices.ContainsFunc(priorMethod.ExcludeTargets, func(priorTarget authMethodExcludeTargetModel) bool {
+				return priorTarget.ID.Equal(target.ID)
+			})
+	})
+
+	// Keep an explicitly empty set of exclusions from showing as drift.
+	if len(excludeTargets) == 0 {
+		excludeTargets = nil
+		if priorMethod.ExcludeTargets != nil {
+			excludeTargets = []authMethodExcludeTargetModel{}
+		}
+	}
+
+	method := authenticationMethodModel{
+		Type:           priorMethod.Type,
+		ExcludeTargets: excludeTargets,
+	}
+	if len(priorMethod.IncludeTargets) > 0 {
+		method.IncludeTargets = sortIncludeTargets(getIncludeTargets(config), priorMethod.IncludeTargets)
+	}
+
+	return method
+}
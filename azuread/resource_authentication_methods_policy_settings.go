@@ -0,0 +1,914 @@
+package azuread
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	graph "github.com/microsoftgraph/msgraph-sdk-go"
+	graphModels "github.com/microsoftgraph/msgraph-sdk-go/models"
+)
+
+var (
+	_ resource.Resource                   = &authenticationMethodsPolicySettingsResource{}
+	_ resource.ResourceWithConfigure      = &authenticationMethodsPolicySettingsResource{}
+	_ resource.ResourceWithValidateConfig = &authenticationMethodsPolicySettingsResource{}
+	_ resource.ResourceWithImportState    = &authenticationMethodsPolicySettingsResource{}
+)
+
+func NewAuthenticationMethodsPolicySettingsResource() resource.Resource {
+	return &authenticationMethodsPolicySettingsResource{}
+}
+
+type authenticationMethodsPolicySettingsResource struct {
+	client *graph.GraphServiceClient
+
+	// The group IDs excluded from the registration campaign and the
+	// system-preferred multifactor authentication.
+	alwaysExcludedGroupIDs []string
+}
+
+type authenticationMethodsPolicySettingsResourceModel struct {
+	ID                          types.String                      `tfsdk:"id"`
+	PolicyMigrationState        types.String                      `tfsdk:"policy_migration_state"`
+	RegistrationCampaign        *registrationCampaignModel        `tfsdk:"registration_campaign"`
+	SystemCredentialPreferences *systemCredentialPreferencesModel `tfsdk:"system_credential_preferences"`
+	ReportSuspiciousActivity    *reportSuspiciousActivityModel    `tfsdk:"report_suspicious_activity"`
+}
+
+type registrationCampaignModel struct {
+	State                                  types.String                             `tfsdk:"state"`
+	SnoozeDurationInDays                   types.Int64                              `tfsdk:"snooze_duration_in_days"`
+	EnforceRegistrationAfterAllowedSnoozes types.Bool                               `tfsdk:"enforce_registration_after_allowed_snoozes"`
+	IncludeTargets                         []registrationCampaignIncludeTargetModel `tfsdk:"include_targets"`
+	ExcludeTargets                         []policyTargetModel                      `tfsdk:"exclude_targets"`
+}
+
+type registrationCampaignIncludeTargetModel struct {
+	ID                           types.String `tfsdk:"id"`
+	TargetType                   types.String `tfsdk:"target_type"`
+	TargetedAuthenticationMethod types.String `tfsdk:"targeted_authentication_method"`
+}
+
+type systemCredentialPreferencesModel struct {
+	State          types.String        `tfsdk:"state"`
+	IncludeTargets []policyTargetModel `tfsdk:"include_targets"`
+	ExcludeTargets []policyTargetModel `tfsdk:"exclude_targets"`
+}
+
+type reportSuspiciousActivityModel struct {
+	State              types.String `tfsdk:"state"`
+	IncludeTargetID    types.String `tfsdk:"include_target_id"`
+	IncludeTargetType  types.String `tfsdk:"include_target_type"`
+	VoiceReportingCode types.Int64  `tfsdk:"voice_reporting_code"`
+}
+
+type policyTargetModel struct {
+	ID         types.String `tfsdk:"id"`
+	TargetType types.String `tfsdk:"target_type"`
+}
+
+// The path of the authentication methods policy on Microsoft Graph API.
+const authenticationMethodsPolicyPath = "/policies/authenticationMethodsPolicy"
+
+// The ranges and defaults of the settings accepted by Graph API.
+const (
+	registrationCampaignMinSnoozeDurationInDays     = 0
+	registrationCampaignMaxSnoozeDurationInDays     = 14
+	registrationCampaignDefaultSnoozeDurationInDays = 1
+
+	reportSuspiciousActivityMinVoiceReportingCode = 0
+	reportSuspiciousActivityMaxVoiceReportingCode = 9
+)
+
+func (r *authenticationMethodsPolicySettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authentication_methods_policy_settings"
+}
+
+func (r *authenticationMethodsPolicySettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the top-level settings of the authentication methods policy on Microsoft " +
+			"Entra ID. The settings are managed through the Microsoft Graph API beta endpoint, as the " +
+			"system-preferred multifactor authentication is not available in the v1.0 endpoint. " +
+			"Destroying the resource restores the managed settings to their defaults.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the authentication methods policy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_migration_state": schema.StringAttribute{
+				Description: "The state of the migration of the legacy multifactor authentication and " +
+					"self-service password reset policies to the authentication methods policy. Possible " +
+					"values are `preMigration`, `migrationInProgress` or `migrationComplete`. Left " +
+					"unchanged on destroy.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"registration_campaign": schema.SingleNestedBlock{
+				Description: "The campaign nudging users to register the Microsoft Authenticator app " +
+					"during sign in. If not configured, the campaign on Microsoft Entra ID is left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"state": advancedConfigStateAttribute("The state of the registration campaign."),
+					"snooze_duration_in_days": schema.Int64Attribute{
+						Description: fmt.Sprintf("The number of days a user may postpone the registration, "+
+							"between `%d` and `%d`. Defaults to `%d`.", registrationCampaignMinSnoozeDurationInDays,
+							registrationCampaignMaxSnoozeDurationInDays, registrationCampaignDefaultSnoozeDurationInDays),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(registrationCampaignDefaultSnoozeDurationInDays),
+					},
+					"enforce_registration_after_allowed_snoozes": schema.BoolAttribute{
+						Description: "Whether the registration is enforced once the user has postponed " +
+							"it three times. Defaults to `true`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(true),
+					},
+					"include_targets": schema.SetNestedAttribute{
+						Description: "The users or groups targeted by the registration campaign. If not " +
+							"configured, the include targets on Microsoft Entra ID are left unchanged.",
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									Description: "The object ID of the user or group to include, or `all_users` " +
+										"to include every user in the tenant.",
+									Required: true,
+								},
+								"target_type": policyTargetTypeAttribute(),
+								"targeted_authentication_method": schema.StringAttribute{
+									Description: "The authentication method the users are nudged to register. " +
+										"Defaults to `microsoftAuthenticator`.",
+									Optional: true,
+									Computed: true,
+									Default:  stringdefault.StaticString("microsoftAuthenticator"),
+								},
+							},
+						},
+					},
+					"exclude_targets": policyTargetsAttribute("A set of users or groups to exclude from the registration campaign. " +
+						"The groups of the provider `always_excluded_group_ids` are always excluded."),
+				},
+			},
+			"system_credential_preferences": schema.SingleNestedBlock{
+				Description: "The system-preferred multifactor authentication, which prompts users for " +
+					"the most secure authentication method they registered. If not configured, the " +
+					"settings on Microsoft Entra ID are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"state": advancedConfigStateAttribute("The state of the system-preferred multifactor authentication."),
+					"include_targets": policyTargetsAttribute("A set of users or groups the system-preferred " +
+						"multifactor authentication applies to. If not configured, the include targets on " +
+						"Microsoft Entra ID are left unchanged."),
+					"exclude_targets": policyTargetsAttribute("A set of users or groups to exclude from the " +
+						"system-preferred multifactor authentication. The groups of the provider " +
+						"`always_excluded_group_ids` are always excluded."),
+				},
+			},
+			"report_suspicious_activity": schema.SingleNestedBlock{
+				Description: "The settings allowing users to report suspicious multifactor authentication " +
+					"prompts. If not configured, the settings on Microsoft Entra ID are left unchanged.",
+				Attributes: map[string]schema.Attribute{
+					"state": advancedConfigStateAttribute("The state of the suspicious activity reporting."),
+					"include_target_id": schema.StringAttribute{
+						Description: "The ID of the group allowed to report suspicious activities. Defaults to `all_users`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString(allUsersTargetID),
+					},
+					"include_target_type": schema.StringAttribute{
+						Description: "The type of the include target. Possible values are `group` or `user`. Defaults to `group`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("group"),
+					},
+					"voice_reporting_code": schema.Int64Attribute{
+						Description: fmt.Sprintf("The code users enter during a voice call to report a "+
+							"suspicious activity, between `%d` and `%d`. Defaults to `0`.",
+							reportSuspiciousActivityMinVoiceReportingCode, reportSuspiciousActivityMaxVoiceReportingCode),
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(0),
+					},
+				},
+			},
+		},
+	}
+}
+
+func advancedConfigStateAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description + " Possible values are `default`, `enabled` or `disabled`. " +
+			"Defaults to `default`.",
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString("default"),
+	}
+}
+
+func policyTargetTypeAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The type of the target. Possible values are `group` or `user`. Defaults to `group`.",
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString("group"),
+	}
+}
+
+func policyTargetsAttribute(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Description: description,
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Description: "The object ID of the user or group, or `all_users` to target every " +
+						"user in the tenant.",
+					Required: true,
+				},
+				"target_type": policyTargetTypeAttribute(),
+			},
+		},
+	}
+}
+
+func (r *authenticationMethodsPolicySettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(azureadClients).graphClient
+	r.alwaysExcludedGroupIDs = req.ProviderData.(azureadClients).alwaysExcludedGroupIDs
+}
+
+func (r *authenticationMethodsPolicySettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config authenticationMethodsPolicySettingsResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.PolicyMigrationState.IsNull() && !config.PolicyMigrationState.IsUnknown() {
+		if migrationState, _ := graphModels.ParseAuthenticationMethodsPolicyMigrationState(config.PolicyMigrationState.ValueString()); migrationState == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("policy_migration_state"),
+				"[INPUT ERROR] Invalid Policy Migration State",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'preMigration', "+
+					"'migrationInProgress' and 'migrationComplete'.", config.PolicyMigrationState.ValueString()),
+			)
+		}
+	}
+
+	validateState := func(statePath path.Path, state types.String) {
+		if state.IsNull() || state.IsUnknown() {
+			return
+		}
+		if advancedConfigState, _ := graphModels.ParseAdvancedConfigState(state.ValueString()); advancedConfigState == nil {
+			resp.Diagnostics.AddAttributeError(
+				statePath,
+				"[INPUT ERROR] Invalid State",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'default', 'enabled' and 'disabled'.",
+					state.ValueString()),
+			)
+		}
+	}
+
+	validateTargetType := func(targetPath path.Path, targetType types.String) {
+		if targetType.IsNull() || targetType.IsUnknown() {
+			return
+		}
+		switch targetType.ValueString() {
+		case "group", "user":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				targetPath,
+				"[INPUT ERROR] Invalid Target Type",
+				fmt.Sprintf("'%v' is invalid, only acceptable values are 'group' and 'user'.",
+					targetType.ValueString()),
+			)
+		}
+	}
+
+	if campaign := config.RegistrationCampaign; campaign != nil {
+		campaignPath := path.Root("registration_campaign")
+		validateState(campaignPath.AtName("state"), campaign.State)
+
+		if !campaign.SnoozeDurationInDays.IsNull() && !campaign.SnoozeDurationInDays.IsUnknown() {
+			if days := campaign.SnoozeDurationInDays.ValueInt64(); days < registrationCampaignMinSnoozeDurationInDays ||
+				days > registrationCampaignMaxSnoozeDurationInDays {
+				resp.Diagnostics.AddAttributeError(
+					campaignPath.AtName("snooze_duration_in_days"),
+					"[INPUT ERROR] Invalid Snooze Duration",
+					fmt.Sprintf("'%d' is invalid, the snooze duration must be between %d and %d days.", days,
+						registrationCampaignMinSnoozeDurationInDays, registrationCampaignMaxSnoozeDurationInDays),
+				)
+			}
+		}
+
+		for _, target := range campaign.IncludeTargets {
+			validateTargetType(campaignPath.AtName("include_targets"), target.TargetType)
+		}
+		for _, target := range campaign.ExcludeTargets {
+			validateTargetType(campaignPath.AtName("exclude_targets"), target.TargetType)
+		}
+	}
+
+	if preferences := config.SystemCredentialPreferences; preferences != nil {
+		preferencesPath := path.Root("system_credential_preferences")
+		validateState(preferencesPath.AtName("state"), preferences.State)
+
+		for _, target := range preferences.IncludeTargets {
+			validateTargetType(preferencesPath.AtName("include_targets"), target.TargetType)
+		}
+		for _, target := range preferences.ExcludeTargets {
+			validateTargetType(preferencesPath.AtName("exclude_targets"), target.TargetType)
+		}
+	}
+
+	if reporting := config.ReportSuspiciousActivity; reporting != nil {
+		reportingPath := path.Root("report_suspicious_activity")
+		validateState(reportingPath.AtName("state"), reporting.State)
+		validateTargetType(reportingPath.AtName("include_target_type"), reporting.IncludeTargetType)
+
+		if !reporting.VoiceReportingCode.IsNull() && !reporting.VoiceReportingCode.IsUnknown() {
+			if code := reporting.VoiceReportingCode.ValueInt64(); code < reportSuspiciousActivityMinVoiceReportingCode ||
+				code > reportSuspiciousActivityMaxVoiceReportingCode {
+				resp.Diagnostics.AddAttributeError(
+					reportingPath.AtName("voice_reporting_code"),
+					"[INPUT ERROR] Invalid Voice Reporting Code",
+					fmt.Sprintf("'%d' is invalid, the voice reporting code must be between %d and %d.", code,
+						reportSuspiciousActivityMinVoiceReportingCode, reportSuspiciousActivityMaxVoiceReportingCode),
+				)
+			}
+		}
+	}
+}
+
+func (r *authenticationMethodsPolicySettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan authenticationMethodsPolicySettingsResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateDiags := r.updateAuthenticationMethodsPolicySettings(&plan)
+	resp.Diagnostics.Append(updateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authenticationMethodsPolicySettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *authenticationMethodsPolicySettingsResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readDiags := r.readAuthenticationMethodsPolicySettings(state)
+	resp.Diagnostics.Append(readDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *authenticationMethodsPolicySettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan authenticationMethodsPolicySettingsResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateDiags := r.updateAuthenticationMethodsPolicySettings(&plan)
+	resp.Diagnostics.Append(updateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Restores the managed settings to their defaults. The policy migration state
+// is left unchanged, as going back to the legacy policies is not wanted.
+func (r *authenticationMethodsPolicySettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *authenticationMethodsPolicySettingsResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defaults := &authenticationMethodsPolicySettingsResourceModel{
+		PolicyMigrationState: types.StringNull(),
+	}
+	allUsers := []policyTargetModel{
+		{
+			ID:         types.StringValue(allUsersTargetID),
+			TargetType: types.StringValue("group"),
+		},
+	}
+
+	if state.RegistrationCampaign != nil {
+		defaults.RegistrationCampaign = &registrationCampaignModel{
+			State:                                  types.StringValue("default"),
+			SnoozeDurationInDays:                   types.Int64Value(registrationCampaignDefaultSnoozeDurationInDays),
+			EnforceRegistrationAfterAllowedSnoozes: types.BoolValue(true),
+			IncludeTargets: []registrationCampaignIncludeTargetModel{
+				{
+					ID:                           types.StringValue(allUsersTargetID),
+					TargetType:                   types.StringValue("group"),
+					TargetedAuthenticationMethod: types.StringValue("microsoftAuthenticator"),
+				},
+			},
+		}
+	}
+	if state.SystemCredentialPreferences != nil {
+		defaults.SystemCredentialPreferences = &systemCredentialPreferencesModel{
+			State:          types.StringValue("default"),
+			IncludeTargets: allUsers,
+		}
+	}
+	if state.ReportSuspiciousActivity != nil {
+		defaults.ReportSuspiciousActivity = &reportSuspiciousActivityModel{
+			State:              types.StringValue("default"),
+			IncludeTargetID:    types.StringValue(allUsersTargetID),
+			IncludeTargetType:  types.StringValue("group"),
+			VoiceReportingCode: types.Int64Value(0),
+		}
+	}
+
+	if defaults.RegistrationCampaign == nil && defaults.SystemCredentialPreferences == nil &&
+		defaults.ReportSuspiciousActivity == nil {
+		return
+	}
+
+	updateDiags := r.updateAuthenticationMethodsPolicySettings(defaults)
+	resp.Diagnostics.Append(updateDiags...)
+}
+
+// Importing manages every setting, so that they are all read into the state.
+func (r *authenticationMethodsPolicySettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != authenticationMethodsPolicyID {
+		resp.Diagnostics.AddError(
+			"[INPUT ERROR] Invalid Import ID",
+			fmt.Sprintf("'%v' is invalid, the authentication methods policy settings can only be "+
+				"imported with the ID '%v'.", req.ID, authenticationMethodsPolicyID),
+		)
+		return
+	}
+
+	state := &authenticationMethodsPolicySettingsResourceModel{
+		ID:                   types.StringValue(authenticationMethodsPolicyID),
+		PolicyMigrationState: types.StringNull(),
+		RegistrationCampaign: &registrationCampaignModel{
+			IncludeTargets: []registrationCampaignIncludeTargetModel{},
+		},
+		SystemCredentialPreferences: &systemCredentialPreferencesModel{
+			IncludeTargets: []policyTargetModel{},
+		},
+		ReportSuspiciousActivity: &reportSuspiciousActivityModel{},
+	}
+
+	setStateDiags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(setStateDiags...)
+}
+
+// getAuthenticationMethodsPolicy gets the authentication methods policy from
+// the beta endpoint. The settings missing from the v1.0 SDK are kept as
+// additional data.
+func (r *authenticationMethodsPolicySettingsResource) getAuthenticationMethodsPolicy() (graphModels.AuthenticationMethodsPolicyable, diag.Diagnostics) {
+	var policy graphModels.AuthenticationMethodsPolicyable
+
+	getPolicy := func() error {
+		result, err := sendBetaRequest(r.client, abstractions.GET, authenticationMethodsPolicyPath, nil,
+			graphModels.CreateAuthenticationMethodsPolicyFromDiscriminatorValue)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		policy = result.(graphModels.AuthenticationMethodsPolicyable)
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(getPolicy, reconnectBackoff)
+
+	if err != nil {
+		return nil, diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"[API ERROR] Unable to Read Authentication Methods Policy Settings",
+				"An unexpected error occurred while reading the Authentication Methods Policy "+
+					"from Microsoft Entra ID via Microsoft Graph API. Please verify that the API "+
+					"permissions are correctly configured.\n\n"+
+					"Microsoft Graph API Error: "+err.Error(),
+			),
+		}
+	}
+
+	return policy, nil
+}
+
+// updateAuthenticationMethodsPolicySettings patches the managed settings, then
+// reads them back to resolve the computed values. The include targets that are
+// not managed are sent back unchanged, as a setting is replaced as a whole.
+func (r *authenticationMethodsPolicySettingsResource) updateAuthenticationMethodsPolicySettings(plan *authenticationMethodsPolicySettingsResourceModel) diag.Diagnostics {
+	currentPolicy, getDiags := r.getAuthenticationMethodsPolicy()
+	if getDiags.HasError() {
+		return getDiags
+	}
+
+	requestBody := graphModels.NewAuthenticationMethodsPolicy()
+	additionalData := map[string]any{}
+
+	if !plan.PolicyMigrationState.IsNull() && !plan.PolicyMigrationState.IsUnknown() {
+		migrationState, _ := graphModels.ParseAuthenticationMethodsPolicyMigrationState(plan.PolicyMigrationState.ValueString())
+		if migrationState != nil {
+			requestBody.SetPolicyMigrationState(migrationState.(*graphModels.AuthenticationMethodsPolicyMigrationState))
+		}
+	}
+
+	if campaign := plan.RegistrationCampaign; campaign != nil {
+		var currentCampaign graphModels.AuthenticationMethodsRegistrationCampaignable
+		if currentPolicy.GetRegistrationEnforcement() != nil {
+			currentCampaign = currentPolicy.GetRegistrationEnforcement().GetAuthenticationMethodsRegistrationCampaign()
+		}
+
+		registrationEnforcement := graphModels.NewRegistrationEnforcement()
+		registrationEnforcement.SetAuthenticationMethodsRegistrationCampaign(getRegistrationCampaign(campaign, currentCampaign,
+			r.alwaysExcludedGroupIDs))
+		requestBody.SetRegistrationEnforcement(registrationEnforcement)
+	}
+
+	if preferences := plan.SystemCredentialPreferences; preferences != nil {
+		currentPreferences, _ := currentPolicy.GetAdditionalData()["systemCredentialPreferences"].(map[string]any)
+
+		preferencesData := map[string]any{
+			"state":          preferences.State.ValueString(),
+			"excludeTargets": getPolicyTargetsData(appendExcludedPolicyTargets(preferences.ExcludeTargets, r.alwaysExcludedGroupIDs)),
+		}
+		if preferences.IncludeTargets != nil {
+			preferencesData["includeTargets"] = getPolicyTargetsData(preferences.IncludeTargets)
+		} else if currentPreferences != nil && currentPreferences["includeTargets"] != nil {
+			preferencesData["includeTargets"] = currentPreferences["includeTargets"]
+		}
+		additionalData["systemCredentialPreferences"] = preferencesData
+	}
+
+	if reporting := plan.ReportSuspiciousActivity; reporting != nil {
+		additionalData["reportSuspiciousActivitySettings"] = map[string]any{
+			"state": reporting.State.ValueString(),
+			"includeTarget": map[string]any{
+				"id":         reporting.IncludeTargetID.ValueString(),
+				"targetType": reporting.IncludeTargetType.ValueString(),
+			},
+			"voiceReportingCode": reporting.VoiceReportingCode.ValueInt64(),
+		}
+	}
+
+	if len(additionalData) > 0 {
+		requestBody.SetAdditionalData(additionalData)
+	}
+
+	updatePolicy := func() error {
+		_, err := sendBetaRequest(r.client, abstractions.PATCH, authenticationMethodsPolicyPath, requestBody, nil)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(updatePolicy, reconnectBackoff)
+
+	if err != nil {
+		return diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"[API ERROR] Unable to Update Authentication Methods Policy Settings",
+				"An unexpected error occurred while updating the Authentication Methods Policy "+
+					"on Microsoft Entra ID via Microsoft Graph API. Please verify that the provided "+
+					"inputs are correct and that the API permissions are "+
+					"correctly configured.\n\n"+
+					"Microsoft Graph API Error: "+err.Error(),
+			),
+		}
+	}
+
+	plan.ID = types.StringValue(authenticationMethodsPolicyID)
+
+	// Only the computed values are resolved from Graph API, so that the state
+	// matches the plan.
+	if plan.PolicyMigrationState.IsUnknown() {
+		updatedPolicy, getDiags := r.getAuthenticationMethodsPolicy()
+		if getDiags.HasError() {
+			return getDiags
+		}
+
+		plan.PolicyMigrationState = types.StringNull()
+		if updatedPolicy.GetPolicyMigrationState() != nil {
+			plan.PolicyMigrationState = types.StringValue(updatedPolicy.GetPolicyMigrationState().String())
+		}
+	}
+
+	return nil
+}
+
+// readAuthenticationMethodsPolicySettings refreshes the managed settings.
+func (r *authenticationMethodsPolicySettingsResource) readAuthenticationMethodsPolicySettings(state *authenticationMethodsPolicySettingsResourceModel) diag.Diagnostics {
+	policy, getDiags := r.getAuthenticationMethodsPolicy()
+	if getDiags.HasError() {
+		return getDiags
+	}
+
+	state.ID = types.StringValue(authenticationMethodsPolicyID)
+	state.PolicyMigrationState = types.StringNull()
+	if policy.GetPolicyMigrationState() != nil {
+		state.PolicyMigrationState = types.StringValue(policy.GetPolicyMigrationState().String())
+	}
+
+	if state.RegistrationCampaign != nil {
+		var campaign graphModels.AuthenticationMethodsRegistrationCampaignable
+		if policy.GetRegistrationEnforcement() != nil {
+			campaign = policy.GetRegistrationEnforcement().GetAuthenticationMethodsRegistrationCampaign()
+		}
+		state.RegistrationCampaign = getRegistrationCampaignModel(campaign, state.RegistrationCampaign,
+			r.alwaysExcludedGroupIDs)
+	}
+
+	if state.SystemCredentialPreferences != nil {
+		preferencesData, _ := policy.GetAdditionalData()["systemCredentialPreferences"].(map[string]any)
+		excludeTargets := removeExcludedPolicyTargets(getPolicyTargetsFromData(preferencesData["excludeTargets"]),
+			state.SystemCredentialPreferences.ExcludeTargets, r.alwaysExcludedGroupIDs)
+		preferences := &systemCredentialPreferencesModel{
+			State:          types.StringNull(),
+			ExcludeTargets: keepEmptyPolicyTargets(excludeTargets, state.SystemCredentialPreferences.ExcludeTargets),
+		}
+		if value, ok := getRawString(preferencesData["state"]); ok {
+			preferences.State = types.StringValue(value)
+		}
+		if state.SystemCredentialPreferences.IncludeTargets != nil {
+			preferences.IncludeTargets = keepEmptyPolicyTargets(getPolicyTargetsFromData(preferencesData["includeTargets"]),
+				state.SystemCredentialPreferences.IncludeTargets)
+		}
+		state.SystemCredentialPreferences = preferences
+	}
+
+	if state.ReportSuspiciousActivity != nil {
+		reportingData, _ := policy.GetAdditionalData()["reportSuspiciousActivitySettings"].(map[string]any)
+		reporting := &reportSuspiciousActivityModel{
+			State:              types.StringNull(),
+			IncludeTargetID:    types.StringNull(),
+			IncludeTargetType:  types.StringNull(),
+			VoiceReportingCode: types.Int64Null(),
+		}
+		if value, ok := getRawString(reportingData["state"]); ok {
+			reporting.State = types.StringValue(value)
+		}
+		if includeTarget, ok := reportingData["includeTarget"].(map[string]any); ok {
+			if value, ok := getRawString(includeTarget["id"]); ok {
+				reporting.IncludeTargetID = types.StringValue(value)
+			}
+			if value, ok := getRawString(includeTarget["targetType"]); ok {
+				reporting.IncludeTargetType = types.StringValue(value)
+			}
+		}
+		if value, ok := getRawInt64(reportingData["voiceReportingCode"]); ok {
+			reporting.VoiceReportingCode = types.Int64Value(value)
+		}
+		state.ReportSuspiciousActivity = reporting
+	}
+
+	return nil
+}
+
+// getRegistrationCampaign converts the registration campaign into the request
+// body, keeping the current include targets if they are not managed. The groups
+// excluded provider-wide are always merged into the exclude targets.
+func getRegistrationCampaign(campaign *registrationCampaignModel,
+	currentCampaign graphModels.AuthenticationMethodsRegistrationCampaignable,
+	alwaysExcludedGroupIDs []string) graphModels.AuthenticationMethodsRegistrationCampaignable {
+	registrationCampaign := graphModels.NewAuthenticationMethodsRegistrationCampaign()
+
+	if state, _ := graphModels.ParseAdvancedConfigState(campaign.State.ValueString()); state != nil {
+		registrationCampaign.SetState(state.(*graphModels.AdvancedConfigState))
+	}
+	snoozeDurationInDays := int32(campaign.SnoozeDurationInDays.ValueInt64())
+	registrationCampaign.SetSnoozeDurationInDays(&snoozeDurationInDays)
+	registrationCampaign.SetAdditionalData(map[string]any{
+		"enforceRegistrationAfterAllowedSnoozes": campaign.EnforceRegistrationAfterAllowedSnoozes.ValueBool(),
+	})
+
+	excludeTargets := []graphModels.ExcludeTargetable{}
+	for _, target := range campaign.ExcludeTargets {
+		excludeTarget := graphModels.NewExcludeTarget()
+		excludeTarget.SetId(StringPtr(target.ID.ValueString()))
+		excludeTarget.SetTargetType(getPolicyTargetType(target.TargetType))
+		excludeTargets = append(excludeTargets, excludeTarget)
+	}
+	registrationCampaign.SetExcludeTargets(appendExcludedGroups(excludeTargets, alwaysExcludedGroupIDs))
+
+	if campaign.IncludeTargets != nil {
+		includeTargets := []graphModels.AuthenticationMethodsRegistrationCampaignIncludeTargetable{}
+		for _, target := range campaign.IncludeTargets {
+			includeTarget := graphModels.NewAuthenticationMethodsRegistrationCampaignIncludeTarget()
+			includeTarget.SetId(StringPtr(target.ID.ValueString()))
+			includeTarget.SetTargetType(getPolicyTargetType(target.TargetType))
+			includeTarget.SetTargetedAuthenticationMethod(StringPtr(target.TargetedAuthenticationMethod.ValueString()))
+			includeTargets = append(includeTargets, includeTarget)
+		}
+		registrationCampaign.SetIncludeTargets(includeTargets)
+	} else if currentCampaign != nil {
+		registrationCampaign.SetIncludeTargets(currentCampaign.GetIncludeTargets())
+	}
+
+	return registrationCampaign
+}
+
+// getRegistrationCampaignModel converts the registration campaign into the
+// resource model. The provider-wide excluded groups are hidden unless they are
+// declared, and the include targets are only refreshed when managed.
+func getRegistrationCampaignModel(campaign graphModels.AuthenticationMethodsRegistrationCampaignable,
+	priorCampaign *registrationCampaignModel, alwaysExcludedGroupIDs []string) *registrationCampaignModel {
+	campaignModel := &registrationCampaignModel{
+		State:                                  types.StringNull(),
+		SnoozeDurationInDays:                   types.Int64Null(),
+		EnforceRegistrationAfterAllowedSnoozes: types.BoolNull(),
+	}
+	if campaign == nil {
+		return campaignModel
+	}
+
+	if campaign.GetState() != nil {
+		campaignModel.State = types.StringValue(campaign.GetState().String())
+	}
+	if campaign.GetSnoozeDurationInDays() != nil {
+		campaignModel.SnoozeDurationInDays = types.Int64Value(int64(*campaign.GetSnoozeDurationInDays()))
+	}
+	if value, ok := getRawBool(campaign.GetAdditionalData()["enforceRegistrationAfterAllowedSnoozes"]); ok {
+		campaignModel.EnforceRegistrationAfterAllowedSnoozes = types.BoolValue(value)
+	}
+
+	var excludeTargets []policyTargetModel
+	for _, target := range campaign.GetExcludeTargets() {
+		if target.GetId() == nil {
+			continue
+		}
+		excludeTarget := policyTargetModel{
+			ID:         types.StringValue(*target.GetId()),
+			TargetType: types.StringNull(),
+		}
+		if target.GetTargetType() != nil {
+			excludeTarget.TargetType = types.StringValue(target.GetTargetType().String())
+		}
+		excludeTargets = append(excludeTargets, excludeTarget)
+	}
+	excludeTargets = removeExcludedPolicyTargets(excludeTargets, priorCampaign.ExcludeTargets, alwaysExcludedGroupIDs)
+	campaignModel.ExcludeTargets = keepEmptyPolicyTargets(excludeTargets, priorCampaign.ExcludeTargets)
+
+	if priorCampaign.IncludeTargets != nil {
+		campaignModel.IncludeTargets = []registrationCampaignIncludeTargetModel{}
+		for _, target := range campaign.GetIncludeTargets() {
+			if target.GetId() == nil {
+				continue
+			}
+			includeTarget := registrationCampaignIncludeTargetModel{
+				ID:                           types.StringValue(*target.GetId()),
+				TargetType:                   types.StringNull(),
+				TargetedAuthenticationMethod: types.StringPointerValue(target.GetTargetedAuthenticationMethod()),
+			}
+			if target.GetTargetType() != nil {
+				includeTarget.TargetType = types.StringValue(target.GetTargetType().String())
+			}
+			campaignModel.IncludeTargets = append(campaignModel.IncludeTargets, includeTarget)
+		}
+	}
+
+	return campaignModel
+}
+
+func getPolicyTargetType(targetType types.String) *graphModels.AuthenticationMethodTargetType {
+	policyTargetType := graphModels.GROUP_AUTHENTICATIONMETHODTARGETTYPE
+	if parsed, _ := graphModels.ParseAuthenticationMethodTargetType(targetType.ValueString()); parsed != nil {
+		policyTargetType = *parsed.(*graphModels.AuthenticationMethodTargetType)
+	}
+	return &policyTargetType
+}
+
+func getPolicyTargetsData(targets []policyTargetModel) []map[string]any {
+	targetsData := []map[string]any{}
+	for _, target := range targets {
+		targetsData = append(targetsData, map[string]any{
+			"id":         target.ID.ValueString(),
+			"targetType": target.TargetType.ValueString(),
+		})
+	}
+	return targetsData
+}
+
+// getPolicyTargetsFromData converts the targets deserialized as additional
+// data, which hold raw JSON values, into the resource model.
+func getPolicyTargetsFromData(data any) []policyTargetModel {
+	var targets []policyTargetModel
+
+	targetsData, _ := data.([]any)
+	for _, target := range targetsData {
+		targetData, ok := target.(map[string]any)
+		if !ok {
+			continue
+		}
+		id, ok := getRawString(targetData["id"])
+		if !ok {
+			continue
+		}
+
+		policyTarget := policyTargetModel{
+			ID:         types.StringValue(id),
+			TargetType: types.StringNull(),
+		}
+		if targetType, ok := getRawString(targetData["targetType"]); ok {
+			policyTarget.TargetType = types.StringValue(targetType)
+		}
+		targets = append(targets, policyTarget)
+	}
+
+	return targets
+}
+
+// keepEmptyPolicyTargets keeps an explicitly empty set of targets from showing
+// as drift.
+func keepEmptyPolicyTargets(targets, priorTargets []policyTargetModel) []policyTargetModel {
+	if len(targets) == 0 && priorTargets != nil {
+		return []policyTargetModel{}
+	}
+	return targets
+}
+
+// appendExcludedPolicyTargets returns the targets with the groups excluded
+// provider-wide merged in, leaving the declared targets untouched.
+func appendExcludedPolicyTargets(targets []policyTargetModel, groupIDs []string) []policyTargetModel {
+	mergedTargets := slices.Clone(targets)
+	for _, groupID := range groupIDs {
+		if slices.ContainsFunc(mergedTargets, func(target policyTargetModel) bool {
+			return target.ID.ValueString() == groupID
+		}) {
+			continue
+		}
+		mergedTargets = append(mergedTargets, policyTargetModel{
+			ID:         types.StringValue(groupID),
+			TargetType: types.StringValue("group"),
+		})
+	}
+	return mergedTargets
+}
+
+// removeExcludedPolicyTargets hides the groups excluded provider-wide, unless
+// they are also declared in the prior targets.
+func removeExcludedPolicyTargets(targets, priorTargets []policyTargetModel, groupIDs []string) []policyTargetModel {
+	return slices.DeleteFunc(targets, func(target policyTargetModel) bool {
+		return slices.Contains(groupIDs, target.ID.ValueString()) &&
+			!slices.ContainsFunc(priorTargets, func(priorTarget policyTargetModel) bool {
+				return priorTarget.ID.Equal(target.ID)
+			})
+	})
+}
@@ -0,0 +1,3 @@
+data "st-azuread_auth_method_modes" "example" {
+  strength = "multiFactor"
+}
@@ -0,0 +1,4 @@
+data "st-azuread_auth_method_policies" "example" {
+  types = ["Fido2", "MicrosoftAuthenticator"]
+  state = "enabled"
+}
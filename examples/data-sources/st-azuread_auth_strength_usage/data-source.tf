@@ -0,0 +1,8 @@
+data "st-azuread_auth_strengths" "example" {
+  names  = ["Passkey or CBA"]
+  strict = true
+}
+
+data "st-azuread_auth_strength_usage" "example" {
+  policy_id = data.st-azuread_auth_strengths.example.ids[0]
+}
@@ -1,3 +1,4 @@
 data "st-azuread_auth_strengths" "example" {
-  names = ["Passwordless MFA", "Phishing-resistant MFA"]
+  names  = ["Passwordless MFA", "Phishing-resistant MFA"]
+  strict = true
 }
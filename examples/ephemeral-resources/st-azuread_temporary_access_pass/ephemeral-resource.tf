@@ -0,0 +1,5 @@
+ephemeral "st-azuread_temporary_access_pass" "example" {
+  user_id             = "user@example.com"
+  lifetime_in_minutes = 60
+  is_usable_once      = true
+}
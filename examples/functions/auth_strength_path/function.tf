@@ -0,0 +1,3 @@
+output "phishing_resistant_auth_strength_path" {
+  value = provider::st-azuread::auth_strength_path(provider::st-azuread::builtin_auth_strength_id("phishing_resistant"))
+}
@@ -0,0 +1,3 @@
+output "phishing_resistant_auth_strength_id" {
+  value = provider::st-azuread::builtin_auth_strength_id("phishing_resistant")
+}
@@ -0,0 +1,9 @@
+resource "st-azuread_auth_strength_policy" "example" {
+  display_name = "Passkey or Authenticator"
+
+  # ["fido2", "password,microsoftAuthenticatorPush"]
+  allowed_combinations = provider::st-azuread::normalize_auth_combinations([
+    "FIDO2",
+    "microsoftAuthenticatorPush, password",
+  ])
+}
@@ -6,4 +6,6 @@ terraform {
   }
 }
 
-provider "st-azuread" {}
+provider "st-azuread" {
+  always_excluded_group_ids = ["xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"]
+}
@@ -1,5 +1,136 @@
 resource "st-azuread_auth_method_policy" "example" {
-  state              = "enabled"
-  type               = "Voice"
-  excluded_group_ids = ["xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"]
+  state = "enabled"
+  type  = "Voice"
+
+  exclude_targets = [
+    {
+      id          = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+      target_type = "group"
+    },
+  ]
+
+  include_target {
+    id                       = "all_users"
+    is_registration_required = false
+  }
+}
+
+resource "st-azuread_auth_method_policy" "authenticator" {
+  state = "enabled"
+  type  = "MicrosoftAuthenticator"
+
+  include_target {
+    id                  = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+    authentication_mode = "push"
+  }
+
+  excluded_group_names = ["Emergency Access Accounts"]
+
+  lockout_protection {
+    max_stranded_users  = 0
+    protected_group_ids = ["xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"]
+  }
+}
+
+resource "st-azuread_auth_method_policy" "fido2" {
+  state = "enabled"
+  type  = "Fido2"
+
+  fido2_settings {
+    is_self_service_registration_allowed = true
+    is_attestation_enforced              = true
+
+    key_restrictions {
+      is_enforced      = true
+      enforcement_type = "allow"
+      aaguids          = ["xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"]
+    }
+  }
+}
+
+resource "st-azuread_auth_method_policy" "authenticator_settings" {
+  state = "enabled"
+  type  = "MicrosoftAuthenticator"
+
+  microsoft_authenticator_settings {
+    is_software_oath_enabled = false
+
+    display_app_information {
+      state = "enabled"
+    }
+
+    display_location_information {
+      state             = "enabled"
+      exclude_target_id = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+    }
+  }
+}
+
+resource "st-azuread_auth_method_policy" "temporary_access_pass" {
+  state = "enabled"
+  type  = "TemporaryAccessPass"
+
+  temporary_access_pass_settings {
+    default_lifetime_in_minutes = 60
+    minimum_lifetime_in_minutes = 60
+    maximum_lifetime_in_minutes = 480
+    default_length              = 8
+    is_usable_once              = true
+  }
+}
+
+resource "st-azuread_auth_method_policy" "x509_certificate" {
+  state = "enabled"
+  type  = "X509Certificate"
+
+  x509_certificate_settings {
+    default_authentication_mode = "x509CertificateSingleFactor"
+
+    user_binding {
+      priority               = 1
+      x509_certificate_field = "PrincipalName"
+      user_property          = "userPrincipalName"
+    }
+
+    user_binding {
+      priority               = 2
+      x509_certificate_field = "RFC822Name"
+      user_property          = "userPrincipalName"
+    }
+
+    rule {
+      type                = "issuerSubject"
+      identifier          = "CN=Contoso Issuing CA"
+      authentication_mode = "x509CertificateMultiFactor"
+    }
+  }
+}
+
+resource "st-azuread_auth_method_policy" "sms" {
+  state = "enabled"
+  type  = "Sms"
+
+  include_target {
+    id                    = "all_users"
+    is_usable_for_sign_in = false
+  }
+}
+
+resource "st-azuread_auth_method_policy" "email" {
+  state = "enabled"
+  type  = "Email"
+
+  email_settings {
+    allow_external_id_to_use_email_otp = "disabled"
+  }
+}
+
+resource "st-azuread_auth_method_policy" "qr_code" {
+  state = "enabled"
+  type  = "QRCodePin"
+
+  qr_code_pin_settings {
+    pin_length                        = 8
+    standard_qr_code_lifetime_in_days = 365
+  }
 }
@@ -0,0 +1 @@
+terraform import st-azuread_auth_strength_combination_configuration.fido2 <policy ID>/<combination configuration ID>
@@ -0,0 +1,17 @@
+resource "st-azuread_auth_strength_combination_configuration" "fido2" {
+  policy_id               = st-azuread_auth_strength_policy.example.id
+  applies_to_combinations = ["fido2"]
+
+  fido2 {
+    allowed_aaguids = ["cb69481e-8ff7-4039-93ec-0a2729a154a8"]
+  }
+}
+
+resource "st-azuread_auth_strength_combination_configuration" "x509_certificate" {
+  policy_id               = st-azuread_auth_strength_policy.example.id
+  applies_to_combinations = ["x509CertificateMultiFactor"]
+
+  x509_certificate {
+    allowed_policy_oids = ["1.2.3.4.5"]
+  }
+}
@@ -0,0 +1 @@
+terraform import st-azuread_auth_strength_policy.example xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
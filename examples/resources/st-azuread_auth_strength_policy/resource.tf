@@ -0,0 +1,9 @@
+resource "st-azuread_auth_strength_policy" "example" {
+  display_name = "Passkey or CBA"
+  description  = "Phishing-resistant sign in with a passkey or a certificate."
+
+  allowed_combinations = [
+    "fido2",
+    "x509CertificateMultiFactor",
+  ]
+}
@@ -0,0 +1 @@
+terraform import st-azuread_authentication_methods_policy.example authenticationMethodsPolicy
@@ -0,0 +1,25 @@
+resource "st-azuread_authentication_methods_policy" "example" {
+  method {
+    type = "Fido2"
+  }
+
+  method {
+    type = "MicrosoftAuthenticator"
+
+    exclude_targets = [
+      {
+        id          = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+        target_type = "group"
+      },
+    ]
+
+    include_target {
+      id                  = "all_users"
+      authentication_mode = "any"
+    }
+  }
+
+  method {
+    type = "TemporaryAccessPass"
+  }
+}
@@ -0,0 +1 @@
+terraform import st-azuread_authentication_methods_policy_settings.example authenticationMethodsPolicy
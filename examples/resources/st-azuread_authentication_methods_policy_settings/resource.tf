@@ -0,0 +1,32 @@
+resource "st-azuread_authentication_methods_policy_settings" "example" {
+  policy_migration_state = "migrationComplete"
+
+  registration_campaign {
+    state                   = "enabled"
+    snooze_duration_in_days = 3
+
+    include_targets = [
+      {
+        id                             = "all_users"
+        target_type                    = "group"
+        targeted_authentication_method = "microsoftAuthenticator"
+      },
+    ]
+
+    exclude_targets = [
+      {
+        id          = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+        target_type = "group"
+      },
+    ]
+  }
+
+  system_credential_preferences {
+    state = "enabled"
+  }
+
+  report_suspicious_activity {
+    state                = "enabled"
+    voice_reporting_code = 0
+  }
+}